@@ -1,14 +1,18 @@
 package main
 
 import (
+	"fmt"
 	"github.com/gorilla/mux"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"testAvito/config"
 	"testAvito/handlers"
 	"testAvito/middleware"
+	"testAvito/storage"
 	"testAvito/utils"
+	"time"
 
 	httpSwagger "github.com/swaggo/http-swagger"
 	_ "testAvito/docs" // Обратите внимание, что это подключение сгенерированной документации
@@ -19,9 +23,26 @@ import (
 // @description API для управления тендерами и предложениями
 // @host localhost:8080
 // @BasePath /api
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description Токен в формате "Bearer <token>", выдается ручкой /auth/login
 func main() {
 	config.LoadEnv()
+	// Управление миграциями схемы без запуска сервера: migrate up|down [N]|status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		runMigrate(os.Args[2:])
+		return
+	}
 	utils.InitDB()
+	if err := utils.LoadServiceCategories(config.ServiceCategoriesFile()); err != nil {
+		log.Println("Ошибка загрузки справочника категорий услуг:", err)
+	}
+	files, err := storage.NewLocalStorage(config.AttachmentsDir())
+	if err != nil {
+		log.Fatalf("Ошибка подготовки хранилища файлов: %v", err)
+	}
+	storage.Files = files
 	r := mux.NewRouter()
 
 	// Добавление Swagger UI по пути /swagger/
@@ -30,20 +51,63 @@ func main() {
 	// Пути для тендеров
 	apiRouter := r.PathPrefix("/api").Subrouter()
 	middleware.JSONMiddleware(apiRouter)
+	// Авторизация по bearer-токену для всех ручек API
+	apiRouter.Use(middleware.AuthMiddleware)
 	tenderRouter := apiRouter.PathPrefix("/tenders").Subrouter()
 	bidsRouter := apiRouter.PathPrefix("/bids").Subrouter()
+	employeesRouter := apiRouter.PathPrefix("/employees").Subrouter()
+	organizationsRouter := apiRouter.PathPrefix("/organizations").Subrouter()
+	attachmentsRouter := apiRouter.PathPrefix("/attachments").Subrouter()
 
 	// Проверяющая функция что связь с сервером установлена (возвращает 200 и ок)
 	apiRouter.HandleFunc("/ping", handlers.PingHandler).Methods("GET")
 
+	// Выдача токена доступа
+	apiRouter.HandleFunc("/auth/login", handlers.LoginHandler).Methods("POST")
+
+	// Справочник категорий услуг
+	apiRouter.HandleFunc("/categories", handlers.GetServiceCategoriesHandler).Methods("GET")
+
 	// Все ручки связанные с тендером
 	tenderRouter.HandleFunc("", handlers.TenderShowHandler).Methods("GET")
 	tenderRouter.HandleFunc("/new", handlers.CreateTenderHandler).Methods("POST")
 	tenderRouter.HandleFunc("/{tenderId}/status", handlers.SetStatusTenderHandler).Methods("PUT")
 	tenderRouter.HandleFunc("/{tenderId}/status", handlers.GetStatusTenderHandler).Methods("GET")
 	tenderRouter.HandleFunc("/my", handlers.ShowTenderUserHandler).Methods("GET")
+	tenderRouter.HandleFunc("/report", handlers.TenderReportHandler).Methods("GET")
+	tenderRouter.HandleFunc("/templates", handlers.GetTenderTemplatesHandler).Methods("GET")
+	tenderRouter.HandleFunc("/templates/{templateId}", handlers.DeleteTenderTemplateHandler).Methods("DELETE")
+	tenderRouter.HandleFunc("/templates/{templateId}/new", handlers.CreateTenderFromTemplateHandler).Methods("POST")
+	tenderRouter.HandleFunc("/{tenderId}/template", handlers.SaveTenderTemplateHandler).Methods("POST")
+	tenderRouter.HandleFunc("/{tenderId}/clone", handlers.CloneTenderHandler).Methods("POST")
 	tenderRouter.HandleFunc("/{tenderId}/edit", handlers.EditTenderHandler).Methods("PATCH")
 	tenderRouter.HandleFunc("/{tenderId}/rollback/{version}", handlers.RollbackTenderHandler).Methods("PUT")
+	tenderRouter.HandleFunc("/{tenderId}/versions", handlers.GetTenderVersionsHandler).Methods("GET")
+	tenderRouter.HandleFunc("/{tenderId}/versions/diff", handlers.GetTenderVersionsDiffHandler).Methods("GET")
+	tenderRouter.HandleFunc("/{tenderId}/schedule", handlers.ScheduleTenderPublicationHandler).Methods("PUT")
+	tenderRouter.HandleFunc("/{tenderId}/approval", handlers.GetTenderApprovalHandler).Methods("GET")
+	tenderRouter.HandleFunc("/{tenderId}/approval", handlers.SubmitTenderApprovalHandler).Methods("POST")
+	tenderRouter.HandleFunc("/{tenderId}/approval", handlers.DecideTenderApprovalHandler).Methods("PUT")
+	tenderRouter.HandleFunc("/{tenderId}/schedule", handlers.CancelTenderPublicationHandler).Methods("DELETE")
+	tenderRouter.HandleFunc("/{tenderId}/lots", handlers.GetTenderLotsHandler).Methods("GET")
+	tenderRouter.HandleFunc("/{tenderId}/lots", handlers.CreateLotHandler).Methods("POST")
+	tenderRouter.HandleFunc("/{tenderId}/lots/{lotId}", handlers.EditLotHandler).Methods("PATCH")
+	tenderRouter.HandleFunc("/{tenderId}/lots/{lotId}", handlers.DeleteLotHandler).Methods("DELETE")
+	tenderRouter.HandleFunc("/{tenderId}/lots/{lotId}/cancel", handlers.CancelLotHandler).Methods("PUT")
+	tenderRouter.HandleFunc("/{tenderId}/criteria", handlers.GetTenderCriteriaHandler).Methods("GET")
+	tenderRouter.HandleFunc("/{tenderId}/criteria", handlers.CreateCriterionHandler).Methods("POST")
+	tenderRouter.HandleFunc("/{tenderId}/criteria/{criterionId}", handlers.DeleteCriterionHandler).Methods("DELETE")
+	tenderRouter.HandleFunc("/{tenderId}/evaluation", handlers.GetTenderEvaluationHandler).Methods("GET")
+	tenderRouter.HandleFunc("/{tenderId}/evaluation/award", handlers.AwardByEvaluationHandler).Methods("PUT")
+	tenderRouter.HandleFunc("/{tenderId}/questions", handlers.GetTenderQuestionsHandler).Methods("GET")
+	tenderRouter.HandleFunc("/{tenderId}/questions", handlers.AskTenderQuestionHandler).Methods("POST")
+	tenderRouter.HandleFunc("/{tenderId}/questions/{questionId}/answer", handlers.AnswerTenderQuestionHandler).Methods("PUT")
+	tenderRouter.HandleFunc("/{tenderId}/invitations", handlers.GetInvitationsHandler).Methods("GET")
+	tenderRouter.HandleFunc("/{tenderId}/invitations", handlers.CreateInvitationHandler).Methods("POST")
+	tenderRouter.HandleFunc("/{tenderId}/invitations/{invitationId}", handlers.DeleteInvitationHandler).Methods("DELETE")
+	tenderRouter.HandleFunc("/{tenderId}/attachments", handlers.GetTenderAttachmentsHandler).Methods("GET")
+	tenderRouter.HandleFunc("/{tenderId}/attachments", handlers.UploadTenderAttachmentHandler).Methods("POST")
+	tenderRouter.HandleFunc("/{tenderId}/amendments", handlers.GetTenderAmendmentsHandler).Methods("GET")
 
 	// Все ручки связанные с предложениями
 	bidsRouter.HandleFunc("/new", handlers.CreateBidHandler).Methods("POST")
@@ -54,11 +118,94 @@ func main() {
 	bidsRouter.HandleFunc("/{bidId}/status", handlers.GetStatusBidHandler).Methods("GET")
 	bidsRouter.HandleFunc("/{bidId}/edit", handlers.EditBidHandler).Methods("PATCH")
 	bidsRouter.HandleFunc("/{bidId}/rollback/{version}", handlers.RollbackBidHandler).Methods("PUT")
+	bidsRouter.HandleFunc("/{bidId}/versions", handlers.GetBidVersionsHandler).Methods("GET")
+	bidsRouter.HandleFunc("/{bidId}/versions/diff", handlers.GetBidVersionsDiffHandler).Methods("GET")
 	bidsRouter.HandleFunc("/{tenderId}/reviews", handlers.GetBidReviewsHandler).Methods("GET")
 	bidsRouter.HandleFunc("/{bidId}/feedback", handlers.SubmitReviewBidByTenderIdHandler).Methods("PUT")
+	bidsRouter.HandleFunc("/{bidId}/lower_price", handlers.LowerBidPriceHandler).Methods("PUT")
+	bidsRouter.HandleFunc("/{bidId}/scores", handlers.SubmitBidScoresHandler).Methods("PUT")
+	bidsRouter.HandleFunc("/{bidId}/reconfirm", handlers.ReconfirmBidHandler).Methods("PUT")
+	bidsRouter.HandleFunc("/{bidId}/award", handlers.SetBidAwardHandler).Methods("PUT")
+	bidsRouter.HandleFunc("/{tenderId}/ranking", handlers.GetAuctionRankingHandler).Methods("GET")
+	bidsRouter.HandleFunc("/{bidId}/attachments", handlers.GetBidAttachmentsHandler).Methods("GET")
+	bidsRouter.HandleFunc("/{bidId}/attachments", handlers.UploadBidAttachmentHandler).Methods("POST")
+
+	// Скачивание и удаление файлов тендеров и предложений
+	attachmentsRouter.HandleFunc("/{attachmentId}", handlers.DownloadAttachmentHandler).Methods("GET")
+	attachmentsRouter.HandleFunc("/{attachmentId}", handlers.DeleteAttachmentHandler).Methods("DELETE")
+
+	// Все ручки связанные с сотрудниками
+	employeesRouter.HandleFunc("", handlers.EmployeeShowHandler).Methods("GET")
+	employeesRouter.HandleFunc("/new", handlers.CreateEmployeeHandler).Methods("POST")
+	employeesRouter.HandleFunc("/{employeeId}", handlers.GetEmployeeHandler).Methods("GET")
+	employeesRouter.HandleFunc("/{employeeId}/edit", handlers.EditEmployeeHandler).Methods("PATCH")
+	employeesRouter.HandleFunc("/{employeeId}", handlers.DeleteEmployeeHandler).Methods("DELETE")
+
+	// Все ручки связанные с организациями и ответственными за них
+	organizationsRouter.HandleFunc("", handlers.OrganizationShowHandler).Methods("GET")
+	organizationsRouter.HandleFunc("/new", handlers.CreateOrganizationHandler).Methods("POST")
+	organizationsRouter.HandleFunc("/{organizationId}", handlers.GetOrganizationHandler).Methods("GET")
+	organizationsRouter.HandleFunc("/{organizationId}/edit", handlers.EditOrganizationHandler).Methods("PATCH")
+	organizationsRouter.HandleFunc("/{organizationId}", handlers.DeleteOrganizationHandler).Methods("DELETE")
+	organizationsRouter.HandleFunc("/{organizationId}/responsibles", handlers.GetOrganizationResponsiblesHandler).Methods("GET")
+	organizationsRouter.HandleFunc("/{organizationId}/responsibles", handlers.AddOrganizationResponsibleHandler).Methods("POST")
+	organizationsRouter.HandleFunc("/{organizationId}/responsibles/{employeeId}", handlers.SetOrganizationResponsibleRoleHandler).Methods("PATCH")
+	organizationsRouter.HandleFunc("/{organizationId}/responsibles/{employeeId}", handlers.RemoveOrganizationResponsibleHandler).Methods("DELETE")
+
+	// Фоновая публикация тендеров по расписанию закрытие тендеров с истекшим сроком подачи предложений и раунды аукционов
+	go handlers.StartTenderWorker(config.WorkerInterval())
 
 	// Запуск сервера
 	add := os.Getenv("SERVER_ADDRESS")
 	log.Printf("Server listen and serve on port %s", add)
 	log.Fatal(http.ListenAndServe(add, r))
 }
+
+// Выполняет команду migrate: up применяет все новые миграции, down откатывает N последних (по умолчанию одну),
+// status показывает примененные и ожидающие миграции
+func runMigrate(args []string) {
+	if len(args) == 0 {
+		log.Fatal("Использование: migrate up | migrate down [N] | migrate status")
+	}
+	utils.ConnectDB()
+
+	switch args[0] {
+	case "up":
+		applied, err := utils.MigrateUp()
+		if err != nil {
+			log.Fatalf("Ошибка применения миграций: %v", err)
+		}
+		log.Printf("Применено миграций: %d", applied)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n < 1 {
+				log.Fatal("Число откатываемых миграций должно быть положительным")
+			}
+			steps = n
+		}
+		reverted, err := utils.MigrateDown(steps)
+		if err != nil {
+			log.Fatalf("Ошибка отката миграций: %v", err)
+		}
+		log.Printf("Откачено миграций: %d", reverted)
+	case "status":
+		states, err := utils.MigrationStatus()
+		if err != nil {
+			log.Fatalf("Ошибка получения состояния миграций: %v", err)
+		}
+		for _, state := range states {
+			status := "не применена"
+			if state.AppliedAt != nil {
+				status = "применена " + state.AppliedAt.Format(time.RFC3339)
+			}
+			if state.Missing {
+				status += ", нет в бинарнике"
+			}
+			fmt.Printf("%06d_%s\t%s\n", state.Version, state.Name, status)
+		}
+	default:
+		log.Fatalf("Неизвестная команда migrate %s, доступны up, down и status", args[0])
+	}
+}
@@ -3,6 +3,22 @@ package config
 import (
 	"github.com/joho/godotenv"
 	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Период запуска фоновых задач по умолчанию
+const defaultWorkerInterval = time.Minute
+
+// Справочник категорий услуг по умолчанию
+const defaultServiceCategoriesFile = "db/service_categories.json"
+
+// Каталог и максимальный размер вложений по умолчанию
+const (
+	defaultAttachmentsDir    = "data/attachments"
+	defaultAttachmentMaxSize = 20 << 20
 )
 
 func LoadEnv() {
@@ -10,3 +26,47 @@ func LoadEnv() {
 		log.Fatal("Ошибка загрузки .env файла")
 	}
 }
+
+// WorkerInterval возвращает период запуска фоновых задач из TENDER_WORKER_INTERVAL
+func WorkerInterval() time.Duration {
+	if interval, err := time.ParseDuration(os.Getenv("TENDER_WORKER_INTERVAL")); err == nil && interval > 0 {
+		return interval
+	}
+	return defaultWorkerInterval
+}
+
+// ServiceCategoriesFile возвращает путь к справочнику категорий услуг из SERVICE_CATEGORIES_FILE
+func ServiceCategoriesFile() string {
+	if path := os.Getenv("SERVICE_CATEGORIES_FILE"); path != "" {
+		return path
+	}
+	return defaultServiceCategoriesFile
+}
+
+// AttachmentsDir возвращает каталог локального хранилища вложений из ATTACHMENTS_DIR
+func AttachmentsDir() string {
+	if dir := os.Getenv("ATTACHMENTS_DIR"); dir != "" {
+		return dir
+	}
+	return defaultAttachmentsDir
+}
+
+// AttachmentMaxSize возвращает максимальный размер вложения в байтах из ATTACHMENT_MAX_SIZE
+func AttachmentMaxSize() int64 {
+	if size, err := strconv.ParseInt(os.Getenv("ATTACHMENT_MAX_SIZE"), 10, 64); err == nil && size > 0 {
+		return size
+	}
+	return defaultAttachmentMaxSize
+}
+
+// AdminUsernames возвращает имена администраторов из ADMIN_USERNAMES (через запятую),
+// которые могут заводить сотрудников, не будучи владельцами организаций
+func AdminUsernames() []string {
+	var usernames []string
+	for _, username := range strings.Split(os.Getenv("ADMIN_USERNAMES"), ",") {
+		if username = strings.TrimSpace(username); username != "" {
+			usernames = append(usernames, username)
+		}
+	}
+	return usernames
+}
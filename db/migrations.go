@@ -0,0 +1,9 @@
+package db
+
+import "embed"
+
+// Migrations версионированные SQL-миграции схемы, встроенные в бинарник.
+// Файлы называются <версия>_<название>.up.sql и <версия>_<название>.down.sql
+//
+//go:embed migrations/*.sql
+var Migrations embed.FS
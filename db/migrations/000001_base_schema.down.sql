@@ -0,0 +1,24 @@
+-- Удаляет базовую схему вместе со всеми данными
+DROP TABLE IF EXISTS attachments;
+DROP TABLE IF EXISTS bid_scores;
+DROP TABLE IF EXISTS bid_feedback;
+DROP TABLE IF EXISTS bid_decisions;
+DROP TABLE IF EXISTS bid_versions;
+DROP TABLE IF EXISTS bids;
+DROP TABLE IF EXISTS tender_templates;
+DROP TABLE IF EXISTS tender_sign_offs;
+DROP TABLE IF EXISTS tender_invitations;
+DROP TABLE IF EXISTS tender_amendments;
+DROP TABLE IF EXISTS tender_questions;
+DROP TABLE IF EXISTS evaluation_criteria;
+DROP TABLE IF EXISTS lots;
+DROP TABLE IF EXISTS tender_versions;
+DROP TABLE IF EXISTS tenders;
+DROP TABLE IF EXISTS service_categories;
+DROP TABLE IF EXISTS organization_responsible;
+DROP TABLE IF EXISTS organization;
+DROP TABLE IF EXISTS employee;
+
+DROP TYPE IF EXISTS bid_status;
+DROP TYPE IF EXISTS tender_status;
+DROP TYPE IF EXISTS organization_type;
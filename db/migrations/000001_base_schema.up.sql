@@ -0,0 +1,303 @@
+-- Базовая схема. Таблицы создаются с IF NOT EXISTS, чтобы миграция проходила и на базах,
+-- которые раньше создавались через AutoMigrate
+
+-- Перечисления статусов и типов организаций
+DO $$ BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'organization_type') THEN
+        CREATE TYPE organization_type AS ENUM ('IE', 'LLC', 'JSC');
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'tender_status') THEN
+        CREATE TYPE tender_status AS ENUM ('CREATED', 'PUBLISHED', 'CLOSED', 'CANCELLED');
+    END IF;
+    IF NOT EXISTS (SELECT 1 FROM pg_type WHERE typname = 'bid_status') THEN
+        CREATE TYPE bid_status AS ENUM ('CREATED', 'PUBLISHED', 'CANCELED', 'APPROVED', 'REJECTED');
+    END IF;
+END $$;
+
+-- Сотрудники
+CREATE TABLE IF NOT EXISTS employee (
+    id            BIGSERIAL PRIMARY KEY,
+    username      TEXT NOT NULL UNIQUE,
+    first_name    TEXT,
+    last_name     TEXT,
+    password_hash TEXT,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+-- Организации
+CREATE TABLE IF NOT EXISTS organization (
+    id                BIGSERIAL PRIMARY KEY,
+    name              VARCHAR(100) NOT NULL,
+    description       TEXT,
+    type              organization_type,
+    quorum_rule       VARCHAR(16) NOT NULL DEFAULT 'FIXED',
+    quorum_value      BIGINT NOT NULL DEFAULT 3,
+    sign_off_required BIGINT NOT NULL DEFAULT 0,
+    created_at        TIMESTAMPTZ,
+    updated_at        TIMESTAMPTZ
+);
+
+-- Ответственные за организацию и их роли
+CREATE TABLE IF NOT EXISTS organization_responsible (
+    id              BIGSERIAL PRIMARY KEY,
+    organization_id BIGINT NOT NULL,
+    user_id         BIGINT NOT NULL,
+    role            VARCHAR(32) NOT NULL DEFAULT 'OWNER',
+    created_at      TIMESTAMPTZ,
+    updated_at      TIMESTAMPTZ
+);
+
+-- Справочник категорий услуг
+CREATE TABLE IF NOT EXISTS service_categories (
+    code        VARCHAR(32) PRIMARY KEY,
+    name        TEXT NOT NULL,
+    parent_code VARCHAR(32)
+);
+
+-- Тендеры
+CREATE TABLE IF NOT EXISTS tenders (
+    id                        BIGSERIAL PRIMARY KEY,
+    name                      TEXT NOT NULL,
+    description               TEXT,
+    service_type              TEXT,
+    status                    tender_status DEFAULT 'CREATED',
+    organization_id           BIGINT NOT NULL,
+    creator_username          TEXT NOT NULL,
+    submission_deadline       TIMESTAMPTZ,
+    publish_at                TIMESTAMPTZ,
+    budget                    NUMERIC(20, 2),
+    currency                  VARCHAR(3),
+    sealed                    BOOLEAN NOT NULL DEFAULT FALSE,
+    visibility                VARCHAR(16) NOT NULL DEFAULT 'PUBLIC',
+    cancellation_reason       TEXT,
+    outcome                   VARCHAR(32),
+    type                      VARCHAR(16) NOT NULL DEFAULT 'STANDARD',
+    max_winners               BIGINT NOT NULL DEFAULT 1,
+    approval_status           VARCHAR(16),
+    approval_round            BIGINT,
+    approvals_required        BIGINT,
+    approval_submitted_by     BIGINT,
+    quorum_rule               VARCHAR(16),
+    quorum_value              BIGINT,
+    auction_step              NUMERIC(20, 2),
+    auction_round_seconds     BIGINT,
+    auction_extension_seconds BIGINT,
+    auction_round             BIGINT,
+    auction_round_bids        BIGINT,
+    auction_round_ends_at     TIMESTAMPTZ,
+    created_at                TIMESTAMPTZ,
+    updated_at                TIMESTAMPTZ,
+    version                   BIGINT DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS tender_versions (
+    id                  BIGSERIAL PRIMARY KEY,
+    tender_id           BIGINT NOT NULL,
+    name                TEXT NOT NULL,
+    description         TEXT,
+    service_type        TEXT,
+    status              TEXT,
+    submission_deadline TIMESTAMPTZ,
+    budget              NUMERIC(20, 2),
+    currency            VARCHAR(3),
+    cancellation_reason TEXT,
+    quorum_rule         VARCHAR(16),
+    quorum_value        BIGINT,
+    version             BIGINT NOT NULL,
+    changed_by          BIGINT,
+    created_at          TIMESTAMPTZ
+);
+
+-- Лоты и критерии оценки тендера
+CREATE TABLE IF NOT EXISTS lots (
+    id            BIGSERIAL PRIMARY KEY,
+    tender_id     BIGINT NOT NULL,
+    name          TEXT NOT NULL,
+    description   TEXT,
+    budget        NUMERIC(20, 2),
+    status        VARCHAR(16) NOT NULL DEFAULT 'OPEN',
+    winner_bid_id BIGINT,
+    created_at    TIMESTAMPTZ,
+    updated_at    TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS evaluation_criteria (
+    id          BIGSERIAL PRIMARY KEY,
+    tender_id   BIGINT NOT NULL,
+    name        TEXT NOT NULL,
+    description TEXT,
+    weight      NUMERIC(10, 2) NOT NULL,
+    created_at  TIMESTAMPTZ
+);
+
+-- Вопросы, изменения условий, приглашения и согласование тендера
+CREATE TABLE IF NOT EXISTS tender_questions (
+    id          BIGSERIAL PRIMARY KEY,
+    tender_id   BIGINT NOT NULL,
+    asker_id    BIGINT NOT NULL,
+    question    TEXT NOT NULL,
+    answer      TEXT,
+    answered_by BIGINT,
+    answered_at TIMESTAMPTZ,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS tender_amendments (
+    id             BIGSERIAL PRIMARY KEY,
+    tender_id      BIGINT NOT NULL,
+    number         BIGINT NOT NULL,
+    tender_version BIGINT NOT NULL,
+    note           TEXT NOT NULL,
+    changed_fields TEXT,
+    author_id      BIGINT NOT NULL,
+    created_at     TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS tender_invitations (
+    id              BIGSERIAL PRIMARY KEY,
+    tender_id       BIGINT NOT NULL,
+    organization_id BIGINT,
+    employee_id     BIGINT,
+    created_at      TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS tender_sign_offs (
+    id          BIGSERIAL PRIMARY KEY,
+    tender_id   BIGINT NOT NULL,
+    round       BIGINT NOT NULL,
+    reviewer_id BIGINT NOT NULL,
+    decision    VARCHAR(16) NOT NULL,
+    comment     TEXT,
+    created_at  TIMESTAMPTZ
+);
+
+-- Шаблоны тендеров, лоты, критерии и приглашения шаблона хранятся в JSON
+CREATE TABLE IF NOT EXISTS tender_templates (
+    id                        BIGSERIAL PRIMARY KEY,
+    organization_id           BIGINT NOT NULL,
+    title                     TEXT NOT NULL,
+    creator_username          TEXT NOT NULL,
+    name                      TEXT NOT NULL,
+    description               TEXT,
+    service_type              TEXT,
+    budget                    NUMERIC(20, 2),
+    currency                  VARCHAR(3),
+    sealed                    BOOLEAN NOT NULL DEFAULT FALSE,
+    visibility                VARCHAR(16) NOT NULL DEFAULT 'PUBLIC',
+    type                      VARCHAR(16) NOT NULL DEFAULT 'STANDARD',
+    auction_step              NUMERIC(20, 2),
+    auction_round_seconds     BIGINT,
+    auction_extension_seconds BIGINT,
+    max_winners               BIGINT NOT NULL DEFAULT 1,
+    quorum_rule               VARCHAR(16),
+    quorum_value              BIGINT,
+    lots                      TEXT,
+    criteria                  TEXT,
+    invitations               TEXT,
+    created_at                TIMESTAMPTZ
+);
+
+-- Предложения
+CREATE TABLE IF NOT EXISTS bids (
+    id                   BIGSERIAL PRIMARY KEY,
+    name                 TEXT NOT NULL,
+    description          TEXT,
+    status               bid_status DEFAULT 'CREATED',
+    tender_id            BIGINT NOT NULL,
+    lot_id               BIGINT,
+    author_type          TEXT NOT NULL,
+    author_id            BIGINT NOT NULL,
+    amount               NUMERIC(20, 2),
+    version              BIGINT DEFAULT 1,
+    needs_reconfirmation BOOLEAN NOT NULL DEFAULT FALSE,
+    award_share          NUMERIC(5, 2),
+    award_quantity       NUMERIC(20, 2),
+    created_at           TIMESTAMPTZ,
+    updated_at           TIMESTAMPTZ
+);
+
+CREATE TABLE IF NOT EXISTS bid_versions (
+    id          BIGSERIAL PRIMARY KEY,
+    bid_id      BIGINT NOT NULL,
+    name        TEXT NOT NULL,
+    description TEXT,
+    status      bid_status DEFAULT 'CREATED',
+    tender_id   BIGINT NOT NULL,
+    author_type TEXT NOT NULL,
+    author_id   BIGINT NOT NULL,
+    amount      NUMERIC(20, 2),
+    version     BIGINT DEFAULT 1,
+    changed_by  BIGINT,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ
+);
+
+-- Раньше ключом bid_versions был bid_id и сохранялась только первая версия предложения
+DO $$ BEGIN
+    IF NOT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'bid_versions' AND column_name = 'id') THEN
+        ALTER TABLE bid_versions DROP CONSTRAINT IF EXISTS bid_versions_pkey;
+        ALTER TABLE bid_versions ADD COLUMN id BIGSERIAL PRIMARY KEY;
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS bid_decisions (
+    id             BIGSERIAL PRIMARY KEY,
+    bid_id         BIGINT NOT NULL,
+    responsible_id BIGINT NOT NULL,
+    decision       TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS bid_feedback (
+    id         BIGSERIAL,
+    bid_id     BIGINT NOT NULL,
+    username   TEXT NOT NULL,
+    feedback   TEXT NOT NULL,
+    created_at TIMESTAMPTZ,
+    PRIMARY KEY (id, bid_id)
+);
+
+CREATE TABLE IF NOT EXISTS bid_scores (
+    id             BIGSERIAL PRIMARY KEY,
+    bid_id         BIGINT NOT NULL,
+    criterion_id   BIGINT NOT NULL,
+    responsible_id BIGINT NOT NULL,
+    score          BIGINT NOT NULL,
+    updated_at     TIMESTAMPTZ
+);
+
+-- Вложения тендеров и предложений
+CREATE TABLE IF NOT EXISTS attachments (
+    id           BIGSERIAL PRIMARY KEY,
+    tender_id    BIGINT,
+    bid_id       BIGINT,
+    file_name    TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    size         BIGINT NOT NULL,
+    sha256       VARCHAR(64) NOT NULL,
+    storage_key  TEXT NOT NULL,
+    uploader_id  BIGINT NOT NULL,
+    created_at   TIMESTAMPTZ
+);
+
+-- Индексы, которые раньше создавал AutoMigrate по тегам моделей
+CREATE INDEX IF NOT EXISTS idx_service_categories_parent_code ON service_categories (parent_code);
+CREATE INDEX IF NOT EXISTS idx_tenders_submission_deadline ON tenders (submission_deadline);
+CREATE INDEX IF NOT EXISTS idx_tenders_publish_at ON tenders (publish_at);
+CREATE INDEX IF NOT EXISTS idx_tenders_auction_round_ends_at ON tenders (auction_round_ends_at);
+CREATE INDEX IF NOT EXISTS idx_lots_tender_id ON lots (tender_id);
+CREATE INDEX IF NOT EXISTS idx_evaluation_criteria_tender_id ON evaluation_criteria (tender_id);
+CREATE INDEX IF NOT EXISTS idx_tender_questions_tender_id ON tender_questions (tender_id);
+CREATE INDEX IF NOT EXISTS idx_tender_amendments_tender_id ON tender_amendments (tender_id);
+CREATE INDEX IF NOT EXISTS idx_tender_invitations_tender_id ON tender_invitations (tender_id);
+CREATE INDEX IF NOT EXISTS idx_tender_invitations_organization_id ON tender_invitations (organization_id);
+CREATE INDEX IF NOT EXISTS idx_tender_invitations_employee_id ON tender_invitations (employee_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tender_sign_off ON tender_sign_offs (tender_id, round, reviewer_id);
+CREATE INDEX IF NOT EXISTS idx_tender_templates_organization_id ON tender_templates (organization_id);
+CREATE INDEX IF NOT EXISTS idx_bids_lot_id ON bids (lot_id);
+CREATE INDEX IF NOT EXISTS idx_bid_versions_bid_id ON bid_versions (bid_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_bid_score ON bid_scores (bid_id, criterion_id, responsible_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_tender_id ON attachments (tender_id);
+CREATE INDEX IF NOT EXISTS idx_attachments_bid_id ON attachments (bid_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_attachments_storage_key ON attachments (storage_key);
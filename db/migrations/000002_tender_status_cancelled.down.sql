@@ -0,0 +1,2 @@
+-- migrate:no-transaction
+-- Значение перечисления нельзя удалить, откат ничего не меняет
@@ -0,0 +1,4 @@
+-- migrate:no-transaction
+-- Статус CANCELLED добавлен позже, в созданный вручную тип tender_status его нужно дописать.
+-- До PostgreSQL 12 ALTER TYPE ... ADD VALUE нельзя выполнять в транзакции, поэтому миграция идет без нее
+ALTER TYPE tender_status ADD VALUE IF NOT EXISTS 'CANCELLED';
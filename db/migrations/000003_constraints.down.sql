@@ -0,0 +1,62 @@
+ALTER TABLE attachments
+    DROP CONSTRAINT IF EXISTS attachments_bid_id_fkey,
+    DROP CONSTRAINT IF EXISTS attachments_tender_id_fkey;
+
+ALTER TABLE bid_scores
+    DROP CONSTRAINT IF EXISTS bid_scores_criterion_id_fkey,
+    DROP CONSTRAINT IF EXISTS bid_scores_bid_id_fkey;
+
+ALTER TABLE bid_feedback
+    DROP CONSTRAINT IF EXISTS bid_feedback_bid_id_username_key,
+    DROP CONSTRAINT IF EXISTS bid_feedback_bid_id_fkey;
+
+ALTER TABLE bid_decisions
+    DROP CONSTRAINT IF EXISTS bid_decisions_bid_id_responsible_id_key,
+    DROP CONSTRAINT IF EXISTS bid_decisions_bid_id_fkey;
+
+DROP INDEX IF EXISTS idx_bid_versions_bid_id_version;
+ALTER TABLE bid_versions DROP CONSTRAINT IF EXISTS bid_versions_bid_id_fkey;
+
+ALTER TABLE lots DROP CONSTRAINT IF EXISTS lots_winner_bid_id_fkey;
+
+DROP INDEX IF EXISTS idx_bids_author;
+DROP INDEX IF EXISTS idx_bids_tender_id;
+ALTER TABLE bids
+    DROP CONSTRAINT IF EXISTS bids_lot_id_fkey,
+    DROP CONSTRAINT IF EXISTS bids_tender_id_fkey;
+
+ALTER TABLE tender_templates DROP CONSTRAINT IF EXISTS tender_templates_organization_id_fkey;
+
+ALTER TABLE tender_sign_offs DROP CONSTRAINT IF EXISTS tender_sign_offs_tender_id_fkey;
+
+ALTER TABLE tender_invitations
+    DROP CONSTRAINT IF EXISTS tender_invitations_tender_id_employee_id_key,
+    DROP CONSTRAINT IF EXISTS tender_invitations_tender_id_organization_id_key,
+    DROP CONSTRAINT IF EXISTS tender_invitations_employee_id_fkey,
+    DROP CONSTRAINT IF EXISTS tender_invitations_organization_id_fkey,
+    DROP CONSTRAINT IF EXISTS tender_invitations_tender_id_fkey;
+
+ALTER TABLE tender_amendments
+    DROP CONSTRAINT IF EXISTS tender_amendments_tender_id_number_key,
+    DROP CONSTRAINT IF EXISTS tender_amendments_tender_id_fkey;
+
+ALTER TABLE tender_questions DROP CONSTRAINT IF EXISTS tender_questions_tender_id_fkey;
+
+ALTER TABLE evaluation_criteria DROP CONSTRAINT IF EXISTS evaluation_criteria_tender_id_fkey;
+
+ALTER TABLE lots DROP CONSTRAINT IF EXISTS lots_tender_id_fkey;
+
+DROP INDEX IF EXISTS idx_tender_versions_tender_id_version;
+ALTER TABLE tender_versions DROP CONSTRAINT IF EXISTS tender_versions_tender_id_fkey;
+
+DROP INDEX IF EXISTS idx_tenders_status;
+DROP INDEX IF EXISTS idx_tenders_organization_id;
+ALTER TABLE tenders DROP CONSTRAINT IF EXISTS tenders_organization_id_fkey;
+
+ALTER TABLE service_categories DROP CONSTRAINT IF EXISTS service_categories_parent_code_fkey;
+
+DROP INDEX IF EXISTS idx_organization_responsible_user_id;
+ALTER TABLE organization_responsible
+    DROP CONSTRAINT IF EXISTS organization_responsible_organization_id_user_id_key,
+    DROP CONSTRAINT IF EXISTS organization_responsible_user_id_fkey,
+    DROP CONSTRAINT IF EXISTS organization_responsible_organization_id_fkey;
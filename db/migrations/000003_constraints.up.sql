@@ -0,0 +1,148 @@
+-- Внешние ключи, уникальность и индексы, которые раньше проверялись только в обработчиках.
+-- Дубликаты, которые могли появиться из-за гонок до этой миграции, удаляются перед добавлением ограничений.
+-- Если в базе есть строки со ссылками на несуществующие объекты, миграция откатится целиком, их нужно исправить вручную
+
+-- Повторные решения и отзывы ответственного по предложению: остается первое
+DELETE FROM bid_decisions a
+    USING bid_decisions b
+    WHERE a.bid_id = b.bid_id AND a.responsible_id = b.responsible_id AND a.id > b.id;
+
+DELETE FROM bid_feedback a
+    USING bid_feedback b
+    WHERE a.bid_id = b.bid_id AND a.username = b.username AND a.id > b.id;
+
+-- Повторные связи сотрудника с организацией: остается связь с ролью OWNER, иначе первая
+DELETE FROM organization_responsible
+    WHERE id IN (
+        SELECT id FROM (
+            SELECT id, ROW_NUMBER() OVER (
+                PARTITION BY organization_id, user_id
+                ORDER BY (role = 'OWNER') DESC, id
+            ) AS position
+            FROM organization_responsible
+        ) ranked
+        WHERE position > 1
+    );
+
+-- Повторные приглашения: остается первое
+DELETE FROM tender_invitations a
+    USING tender_invitations b
+    WHERE a.tender_id = b.tender_id AND a.organization_id = b.organization_id AND a.id > b.id;
+
+DELETE FROM tender_invitations a
+    USING tender_invitations b
+    WHERE a.tender_id = b.tender_id AND a.employee_id = b.employee_id AND a.id > b.id;
+
+-- Изменения условий с одинаковым номером перенумеровываются по порядку создания
+UPDATE tender_amendments t
+    SET number = ranked.position
+    FROM (
+        SELECT id, ROW_NUMBER() OVER (PARTITION BY tender_id ORDER BY number, id) AS position
+        FROM tender_amendments
+    ) ranked
+    WHERE t.id = ranked.id AND t.number <> ranked.position;
+
+-- Ответственные удаляются вместе с организацией или сотрудником. В старой схеме tables_base.sql
+-- эти ключи уже были, поэтому они пересоздаются под теми же именами
+ALTER TABLE organization_responsible
+    DROP CONSTRAINT IF EXISTS organization_responsible_organization_id_fkey,
+    DROP CONSTRAINT IF EXISTS organization_responsible_user_id_fkey,
+    ADD CONSTRAINT organization_responsible_organization_id_fkey
+        FOREIGN KEY (organization_id) REFERENCES organization (id) ON DELETE CASCADE,
+    ADD CONSTRAINT organization_responsible_user_id_fkey
+        FOREIGN KEY (user_id) REFERENCES employee (id) ON DELETE CASCADE,
+    ADD CONSTRAINT organization_responsible_organization_id_user_id_key UNIQUE (organization_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_organization_responsible_user_id ON organization_responsible (user_id);
+
+ALTER TABLE service_categories
+    ADD CONSTRAINT service_categories_parent_code_fkey
+        FOREIGN KEY (parent_code) REFERENCES service_categories (code);
+
+-- Организацию с тендерами удалить нельзя
+ALTER TABLE tenders
+    ADD CONSTRAINT tenders_organization_id_fkey
+        FOREIGN KEY (organization_id) REFERENCES organization (id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_tenders_organization_id ON tenders (organization_id);
+CREATE INDEX IF NOT EXISTS idx_tenders_status ON tenders (status);
+
+ALTER TABLE tender_versions
+    ADD CONSTRAINT tender_versions_tender_id_fkey
+        FOREIGN KEY (tender_id) REFERENCES tenders (id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_tender_versions_tender_id_version ON tender_versions (tender_id, version);
+
+ALTER TABLE lots
+    ADD CONSTRAINT lots_tender_id_fkey
+        FOREIGN KEY (tender_id) REFERENCES tenders (id) ON DELETE CASCADE;
+
+ALTER TABLE evaluation_criteria
+    ADD CONSTRAINT evaluation_criteria_tender_id_fkey
+        FOREIGN KEY (tender_id) REFERENCES tenders (id) ON DELETE CASCADE;
+
+ALTER TABLE tender_questions
+    ADD CONSTRAINT tender_questions_tender_id_fkey
+        FOREIGN KEY (tender_id) REFERENCES tenders (id) ON DELETE CASCADE;
+
+ALTER TABLE tender_amendments
+    ADD CONSTRAINT tender_amendments_tender_id_fkey
+        FOREIGN KEY (tender_id) REFERENCES tenders (id) ON DELETE CASCADE,
+    ADD CONSTRAINT tender_amendments_tender_id_number_key UNIQUE (tender_id, number);
+
+-- Приглашение одной организации или одного сотрудника в тендер может быть только одно
+ALTER TABLE tender_invitations
+    ADD CONSTRAINT tender_invitations_tender_id_fkey
+        FOREIGN KEY (tender_id) REFERENCES tenders (id) ON DELETE CASCADE,
+    ADD CONSTRAINT tender_invitations_organization_id_fkey
+        FOREIGN KEY (organization_id) REFERENCES organization (id) ON DELETE CASCADE,
+    ADD CONSTRAINT tender_invitations_employee_id_fkey
+        FOREIGN KEY (employee_id) REFERENCES employee (id) ON DELETE CASCADE,
+    ADD CONSTRAINT tender_invitations_tender_id_organization_id_key UNIQUE (tender_id, organization_id),
+    ADD CONSTRAINT tender_invitations_tender_id_employee_id_key UNIQUE (tender_id, employee_id);
+
+ALTER TABLE tender_sign_offs
+    ADD CONSTRAINT tender_sign_offs_tender_id_fkey
+        FOREIGN KEY (tender_id) REFERENCES tenders (id) ON DELETE CASCADE;
+
+ALTER TABLE tender_templates
+    ADD CONSTRAINT tender_templates_organization_id_fkey
+        FOREIGN KEY (organization_id) REFERENCES organization (id) ON DELETE CASCADE;
+
+-- Лот, по которому уже поданы предложения, удалить нельзя
+ALTER TABLE bids
+    ADD CONSTRAINT bids_tender_id_fkey
+        FOREIGN KEY (tender_id) REFERENCES tenders (id) ON DELETE CASCADE,
+    ADD CONSTRAINT bids_lot_id_fkey
+        FOREIGN KEY (lot_id) REFERENCES lots (id) ON DELETE RESTRICT;
+CREATE INDEX IF NOT EXISTS idx_bids_tender_id ON bids (tender_id);
+CREATE INDEX IF NOT EXISTS idx_bids_author ON bids (author_type, author_id);
+
+ALTER TABLE lots
+    ADD CONSTRAINT lots_winner_bid_id_fkey
+        FOREIGN KEY (winner_bid_id) REFERENCES bids (id) ON DELETE SET NULL;
+
+ALTER TABLE bid_versions
+    ADD CONSTRAINT bid_versions_bid_id_fkey
+        FOREIGN KEY (bid_id) REFERENCES bids (id) ON DELETE CASCADE;
+CREATE INDEX IF NOT EXISTS idx_bid_versions_bid_id_version ON bid_versions (bid_id, version);
+
+-- Каждый ответственный голосует по предложению и оставляет отзыв один раз
+ALTER TABLE bid_decisions
+    ADD CONSTRAINT bid_decisions_bid_id_fkey
+        FOREIGN KEY (bid_id) REFERENCES bids (id) ON DELETE CASCADE,
+    ADD CONSTRAINT bid_decisions_bid_id_responsible_id_key UNIQUE (bid_id, responsible_id);
+
+ALTER TABLE bid_feedback
+    ADD CONSTRAINT bid_feedback_bid_id_fkey
+        FOREIGN KEY (bid_id) REFERENCES bids (id) ON DELETE CASCADE,
+    ADD CONSTRAINT bid_feedback_bid_id_username_key UNIQUE (bid_id, username);
+
+ALTER TABLE bid_scores
+    ADD CONSTRAINT bid_scores_bid_id_fkey
+        FOREIGN KEY (bid_id) REFERENCES bids (id) ON DELETE CASCADE,
+    ADD CONSTRAINT bid_scores_criterion_id_fkey
+        FOREIGN KEY (criterion_id) REFERENCES evaluation_criteria (id) ON DELETE CASCADE;
+
+ALTER TABLE attachments
+    ADD CONSTRAINT attachments_tender_id_fkey
+        FOREIGN KEY (tender_id) REFERENCES tenders (id) ON DELETE CASCADE,
+    ADD CONSTRAINT attachments_bid_id_fkey
+        FOREIGN KEY (bid_id) REFERENCES bids (id) ON DELETE CASCADE;
@@ -15,104 +15,125 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/bids/my": {
+        "/attachments/{attachmentId}": {
             "get": {
-                "description": "Возвращает список предложений, созданных пользователем с указанным именем (username).",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Отдает файл тендера или предложения с теми же правами, что и просмотр самого тендера или предложения. В заголовке ETag передается SHA-256 содержимого.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Bids"
+                    "Attachments"
                 ],
-                "summary": "Получение предложений пользователя",
+                "summary": "Скачивание файла",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Имя пользователя для поиска предложений",
-                        "name": "username",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "ID файла",
+                        "name": "attachmentId",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список предложений пользователя",
+                        "description": "Содержимое файла",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Bid"
-                            }
+                            "type": "file"
                         }
                     },
                     "400": {
-                        "description": "Имя пользователя пустое",
+                        "description": "Неверный ID файла",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на просмотр предложения",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Пользователь не найден",
+                        "description": "Файл не найден",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка нахождения предложений",
+                        "description": "Ошибка чтения файла",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/bids/new": {
-            "post": {
-                "description": "Создает новое предложение для тендера, проверяет условия и права автора предложения.",
-                "consumes": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Удаляет файл тендера (ответственные, которым разрешено изменять тендер) или предложения (автор предложения), пока тендер не завершен.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Bids"
+                    "Attachments"
                 ],
-                "summary": "Создание нового предложения",
+                "summary": "Удаление файла",
                 "parameters": [
                     {
-                        "description": "Информация о предложении",
-                        "name": "bid",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Bid"
-                        }
+                        "type": "integer",
+                        "description": "ID файла",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное создание предложения",
+                        "description": "Удаленный файл",
                         "schema": {
-                            "$ref": "#/definitions/models.Bid"
+                            "$ref": "#/definitions/models.Attachment"
                         }
                     },
                     "400": {
-                        "description": "Неверно введенное предложение",
+                        "description": "Неверный ID файла или тендер завершен",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение тендера или предложения",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Тендер или пользователь не найдены",
+                        "description": "Файл не найден",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "409": {
-                        "description": "Организация не может отправить предложение на свои тендеры",
+                    "500": {
+                        "description": "Ошибка удаления файла",
                         "schema": {
                             "type": "string"
                         }
@@ -120,9 +141,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/bids/{bidId}/edit": {
-            "patch": {
-                "description": "Изменяет предложение по его ID, если автором является пользователь или член организации.",
+        "/auth/login": {
+            "post": {
+                "description": "Проверяет имя пользователя и пароль и возвращает bearer-токен, который нужно передавать в заголовке Authorization.",
                 "consumes": [
                     "application/json"
                 ],
@@ -130,61 +151,84 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Bids"
+                    "Auth"
                 ],
-                "summary": "Редактирование предложения",
+                "summary": "Вход сотрудника",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID предложения",
-                        "name": "bidId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Имя пользователя",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "description": "Данные для обновления предложения (name, description)",
-                        "name": "bid",
+                        "description": "Имя пользователя и пароль",
+                        "name": "credentials",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "object"
+                            "$ref": "#/definitions/handlers.LoginRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Обновленное предложение",
+                        "description": "Выданный токен",
                         "schema": {
-                            "$ref": "#/definitions/models.Bid"
+                            "$ref": "#/definitions/handlers.LoginResponse"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID предложения, имя пользователя или данные предложения",
+                        "description": "Неверные данные",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "403": {
-                        "description": "Нет прав для редактирования предложения",
+                    "401": {
+                        "description": "Неверное имя пользователя или пароль",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Предложение или пользователь не найдены",
+                    "500": {
+                        "description": "Ошибка выпуска токена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bids/my": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список предложений, созданных авторизованным пользователем.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bids"
+                ],
+                "summary": "Получение предложений пользователя",
+                "responses": {
+                    "200": {
+                        "description": "Список предложений пользователя",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Bid"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сохранения предложения",
+                        "description": "Ошибка нахождения предложений",
                         "schema": {
                             "type": "string"
                         }
@@ -192,9 +236,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bids/{bidId}/feedback": {
-            "put": {
-                "description": "Добавляет отзыв по предложению, если пользователь имеет право принимать решение. Проверяет, было ли уже добавлено решение по данному предложению.",
+        "/bids/new": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает новое предложение для тендера, проверяет условия, срок подачи предложений и права автора предложения.",
                 "consumes": [
                     "application/json"
                 ],
@@ -204,63 +253,51 @@ const docTemplate = `{
                 "tags": [
                     "Bids"
                 ],
-                "summary": "Добавление отзыва по предложению",
+                "summary": "Создание нового предложения",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "ID предложения",
-                        "name": "bidId",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Решение по предложению (одобрено или отклонено)",
-                        "name": "bidFeedback",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Имя пользователя, принимающего решение",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
+                        "description": "Информация о предложении",
+                        "name": "bid",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Bid"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Отзыв успешно сохранен",
+                        "description": "Успешное создание предложения",
                         "schema": {
-                            "$ref": "#/definitions/models.BidFeedback"
+                            "$ref": "#/definitions/models.Bid"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID предложения или пустое имя пользователя или отзыв",
+                        "description": "Неверно введенное предложение",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "403": {
-                        "description": "Нет прав для принятия решения по предложению",
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Пользователь, предложение или тендер не найдены",
+                    "403": {
+                        "description": "Автор не приглашен к участию в тендере",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "409": {
-                        "description": "Решение по данному предложению уже было принято",
+                    "404": {
+                        "description": "Тендер или пользователь не найдены",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Ошибка сохранения решения",
+                    "409": {
+                        "description": "Организация не может отправить предложение на свои тендеры",
                         "schema": {
                             "type": "string"
                         }
@@ -268,19 +305,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/bids/{bidId}/rollback/{version}": {
-            "put": {
-                "description": "Откатывает предложение к указанной версии, если автором является пользователь или член организации, и предложение не утверждено или отменено.",
-                "consumes": [
-                    "application/json"
+        "/bids/{bidId}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Возвращает метаданные файлов предложения его автору и ответственным за тендер. Файлы предложений закрытого (sealed) тендера ответственные видят только после вскрытия.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Bids"
+                    "Attachments"
                 ],
-                "summary": "Откат предложения к версии",
+                "summary": "Список файлов предложения",
                 "parameters": [
                     {
                         "type": "integer",
@@ -288,69 +327,67 @@ const docTemplate = `{
                         "name": "bidId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Версия, к которой откатывается предложение",
-                        "name": "version",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Имя пользователя, инициирующего откат",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешное откатывание предложения",
+                        "description": "Список файлов",
                         "schema": {
-                            "$ref": "#/definitions/models.Bid"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Attachment"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверный ID предложения, версия или имя пользователя",
+                        "description": "Неверный ID предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "403": {
-                        "description": "Нет прав для откатывания версии предложения",
+                        "description": "Нет прав на просмотр предложения",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Предложение, пользователь или версия не найдены",
+                        "description": "Предложение не найдено",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка обновления предложения",
+                        "description": "Ошибка загрузки файлов",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/bids/{bidId}/status": {
-            "get": {
-                "description": "Возвращает статус предложения, если пользователь имеет права на просмотр статуса.",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Принимает multipart/form-data с файлом в поле file. Загружать файлы может автор предложения (для организации — ответственные с правом подавать предложения), пока предложение не отменено и не истек срок подачи предложений.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Bids"
+                    "Attachments"
                 ],
-                "summary": "Получение статуса предложения",
+                "summary": "Загрузка файла к предложению",
                 "parameters": [
                     {
                         "type": "integer",
@@ -360,48 +397,67 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Имя пользователя, запрашивающего статус",
-                        "name": "username",
-                        "in": "query",
+                        "type": "file",
+                        "description": "Файл",
+                        "name": "file",
+                        "in": "formData",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Статус предложения",
+                        "description": "Сохраненное вложение",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.Attachment"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID предложения или никнейм не введен",
+                        "description": "Неверный файл, ID или статус предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "403": {
-                        "description": "Нет прав для просмотра статуса предложения",
+                        "description": "Нет прав на изменение предложения",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Предложение или пользователь не найдены",
+                        "description": "Предложение не найдено",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера",
+                        "description": "Ошибка сохранения файла",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/bids/{bidId}/award": {
             "put": {
-                "description": "Изменяет статус предложения на основании прав автора. Статус может быть изменен на 'CANCELED', но не на 'PUBLISHED' или 'CREATED', так как эти статусы устанавливаются автоматически.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Задает победившему (PUBLISHED) предложению долю share в процентах и/или объем поставки quantity. Сумма долей победителей тендера (или лота) не может превышать 100%. Доступно ответственным с правом закрывать тендер.",
                 "consumes": [
                     "application/json"
                 ],
@@ -411,7 +467,7 @@ const docTemplate = `{
                 "tags": [
                     "Bids"
                 ],
-                "summary": "Установка статуса предложения",
+                "summary": "Доля победителя",
                 "parameters": [
                     {
                         "type": "integer",
@@ -421,18 +477,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Имя пользователя, изменяющего статус",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
+                        "description": "Доля и объем поставки",
+                        "name": "award",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AwardRequest"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "Новый статус предложения ('CANCELED')",
-                        "name": "status",
-                        "in": "query",
-                        "required": true
+                        "description": "ETag версии, которую видел клиент",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -440,28 +497,46 @@ const docTemplate = `{
                         "description": "Обновленное предложение",
                         "schema": {
                             "$ref": "#/definitions/models.Bid"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия объекта после изменения"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверный статус, ID предложения или имя пользователя",
+                        "description": "Неверные данные, ID или предложение не победило",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "403": {
-                        "description": "Нет прав для изменения статуса предложения",
+                        "description": "Нет прав присуждать тендер",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Предложение, тендер или пользователь не найдены",
+                        "description": "Предложение или тендер не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Версия устарела, объект изменен другим пользователем",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка обновления статуса",
+                        "description": "Ошибка сохранения доли",
                         "schema": {
                             "type": "string"
                         }
@@ -469,9 +544,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bids/{bidId}/submit_decision": {
-            "put": {
-                "description": "Добавляет решение (\"Approved\" или \"Rejected\") по предложению на основании прав пользователя. Проверяет наличие кворума для публикации предложения.",
+        "/bids/{bidId}/edit": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Изменяет предложение по его ID, если автором является пользователь или член организации.",
                 "consumes": [
                     "application/json"
                 ],
@@ -481,7 +561,7 @@ const docTemplate = `{
                 "tags": [
                     "Bids"
                 ],
-                "summary": "Добавление решения по предложению",
+                "summary": "Редактирование предложения",
                 "parameters": [
                     {
                         "type": "integer",
@@ -491,18 +571,19 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Решение по предложению ('Approved' или 'Rejected')",
-                        "name": "decision",
-                        "in": "query",
-                        "required": true
+                        "description": "Данные для обновления предложения (name, description, amount)",
+                        "name": "bid",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
                     },
                     {
                         "type": "string",
-                        "description": "Имя пользователя, принимающего решение",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
+                        "description": "ETag версии, которую видел клиент",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -510,34 +591,46 @@ const docTemplate = `{
                         "description": "Обновленное предложение",
                         "schema": {
                             "$ref": "#/definitions/models.Bid"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия объекта после изменения"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверное решение, ID предложения или имя пользователя",
+                        "description": "Неверный ID предложения или данные предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "403": {
-                        "description": "Нет прав для принятия решения по предложению",
+                        "description": "Нет прав для редактирования предложения",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Пользователь, предложение или тендер не найдены",
+                        "description": "Предложение или пользователь не найдены",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "409": {
-                        "description": "Решение по данному предложению уже было принято",
+                    "412": {
+                        "description": "Версия устарела, объект изменен другим пользователем",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сохранения решения или публикации предложения",
+                        "description": "Ошибка сохранения предложения",
                         "schema": {
                             "type": "string"
                         }
@@ -545,9 +638,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bids/{tenderId}/list": {
-            "get": {
-                "description": "Возвращает список предложений для указанного тендера, если пользователь имеет право на просмотр.",
+        "/bids/{bidId}/feedback": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет отзыв по предложению, если пользователь имеет право принимать решение. Проверяет, было ли уже добавлено решение по данному предложению.",
                 "consumes": [
                     "application/json"
                 ],
@@ -557,53 +655,62 @@ const docTemplate = `{
                 "tags": [
                     "Bids"
                 ],
-                "summary": "Получение предложений по TenderID",
+                "summary": "Добавление отзыва по предложению",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID тендера",
-                        "name": "tenderId",
+                        "description": "ID предложения",
+                        "name": "bidId",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Имя пользователя для проверки прав доступа",
-                        "name": "username",
+                        "description": "Решение по предложению (одобрено или отклонено)",
+                        "name": "bidFeedback",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список предложений",
+                        "description": "Отзыв успешно сохранен",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Bid"
-                            }
+                            "$ref": "#/definitions/models.BidFeedback"
                         }
                     },
                     "400": {
-                        "description": "Неверный тендер ID или пустое имя пользователя",
+                        "description": "Неверный ID предложения или пустой отзыв",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "403": {
-                        "description": "Нет прав на получение списка предложений",
+                        "description": "Нет прав для принятия решения по предложению",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Тендер или пользователь не найдены",
+                        "description": "Пользователь, предложение или тендер не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Решение по данному предложению уже было принято",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка получения предложений",
+                        "description": "Ошибка сохранения решения",
                         "schema": {
                             "type": "string"
                         }
@@ -611,9 +718,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/bids/{tenderId}/reviews": {
-            "get": {
-                "description": "Возвращает отзывы по предложениям автора (authorUsername), связанным с тендером, если пользователь-запросчик (requesterUsername) имеет права доступа.",
+        "/bids/{bidId}/lower_price": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Снижает цену существующего предложения в текущем раунде аукциона. Новая цена должна быть ниже текущей лучшей цены как минимум на шаг аукциона. Предложение, поступившее в окно продления перед концом раунда, продлевает раунд.",
                 "consumes": [
                     "application/json"
                 ],
@@ -621,62 +733,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Bids"
+                    "Auctions"
                 ],
-                "summary": "Получение отзывов по предложениям пользователя",
+                "summary": "Снижение цены в аукционе",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID тендера",
-                        "name": "tenderId",
+                        "description": "ID предложения",
+                        "name": "bidId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Имя пользователя, автора предложений",
-                        "name": "authorUsername",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Имя пользователя, запрашивающего данные",
-                        "name": "requesterUsername",
-                        "in": "query",
-                        "required": true
+                        "description": "Новая цена предложения",
+                        "name": "price",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LowerPriceRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список отзывов по предложениям",
+                        "description": "Предложение с новой ценой",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.BidFeedback"
-                            }
+                            "$ref": "#/definitions/models.Bid"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID тендера или отсутствует authorUsername/requesterUsername",
+                        "description": "Неверная цена, ID предложения или аукцион не идет",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "403": {
-                        "description": "Нет доступа к просмотру обратной связи",
+                        "description": "Нет прав для изменения предложения",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Тендер или пользователь не найден, или нет предложений",
+                        "description": "Предложение или тендер не найдены",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка загрузки данных",
+                        "description": "Ошибка сохранения предложения",
                         "schema": {
                             "type": "string"
                         }
@@ -684,25 +794,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/ping": {
-            "get": {
-                "description": "Возвращает \"ok\", если сервер работает.",
+        "/bids/{bidId}/reconfirm": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Автор подтверждает, что предложение остается в силе на измененных условиях тендера, пока идет прием предложений. Сумма предложения проверяется по новой максимальной цене тендера или лота; если она больше не подходит, предложение нужно отредактировать через edit.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Health"
+                    "Amendments"
+                ],
+                "summary": "Подтверждение предложения",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Проверка состояния сервера",
                 "responses": {
                     "200": {
-                        "description": "ok",
+                        "description": "Подтвержденное предложение",
+                        "schema": {
+                            "$ref": "#/definitions/models.Bid"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID, прием предложений завершен, предложение не требует подтверждения или не укладывается в новые условия",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав для изменения предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение или тендер не найдены",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера",
+                        "description": "Ошибка сохранения предложения",
                         "schema": {
                             "type": "string"
                         }
@@ -710,9 +858,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tenders": {
-            "get": {
-                "description": "Возвращает список всех тендеров с возможностью фильтрации по типу услуг.",
+        "/bids/{bidId}/rollback/{version}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Откатывает предложение к указанной версии, если автором является пользователь или член организации, и предложение не утверждено или отменено.",
                 "consumes": [
                     "application/json"
                 ],
@@ -720,29 +873,76 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Bids"
                 ],
-                "summary": "Получение списка тендеров",
+                "summary": "Откат предложения к версии",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Версия, к которой откатывается предложение",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "string",
-                        "description": "Тип услуг для фильтрации тендеров",
-                        "name": "serviceType",
-                        "in": "query"
+                        "description": "ETag версии, которую видел клиент",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список тендеров",
+                        "description": "Успешное откатывание предложения",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.Tender"
+                            "$ref": "#/definitions/models.Bid"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия объекта после изменения"
                             }
                         }
                     },
+                    "400": {
+                        "description": "Неверный ID предложения, версия или сумма версии превышает текущую максимальную цену",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав для откатывания версии предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение, пользователь или версия не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Версия устарела, объект изменен другим пользователем",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "500": {
-                        "description": "Ошибка загрузки тендеров",
+                        "description": "Ошибка обновления предложения",
                         "schema": {
                             "type": "string"
                         }
@@ -750,9 +950,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tenders/my": {
-            "get": {
-                "description": "Возвращает список всех тендеров, созданных пользователем по его имени.",
+        "/bids/{bidId}/scores": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ответственный с правом голоса ставит предложению оценки от 0 до 10 по критериям тендера. Повторная оценка по критерию заменяет прежнюю.",
                 "consumes": [
                     "application/json"
                 ],
@@ -760,30 +965,63 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Evaluation"
                 ],
-                "summary": "Получение тендеров пользователя",
+                "summary": "Оценка предложения по критериям",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Имя пользователя, создавшего тендеры",
-                        "name": "username",
-                        "in": "query",
+                        "type": "integer",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Оценки по критериям",
+                        "name": "scores",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ScoreRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Список тендеров пользователя",
+                        "description": "Оценки ответственного по предложению",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.Tender"
+                                "$ref": "#/definitions/models.BidScore"
                             }
                         }
                     },
-                    "500": {
-                        "description": "Ошибка поиска тендеров",
+                    "400": {
+                        "description": "Неверные оценки, ID предложения или статус тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав оценивать предложение",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение или тендер не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения оценок",
                         "schema": {
                             "type": "string"
                         }
@@ -791,55 +1029,79 @@ const docTemplate = `{
                 }
             }
         },
-        "/tenders/new": {
-            "post": {
-                "description": "Создает новый тендер, декодируя данные из тела запроса и сохраняя их в базе данных.",
+        "/bids/{bidId}/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает статус предложения, если пользователь имеет права на просмотр статуса.",
                 "consumes": [
                     "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "text/plain"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Bids"
                 ],
-                "summary": "Создание нового тендера",
+                "summary": "Получение статуса предложения",
                 "parameters": [
                     {
-                        "description": "Данные для создания тендера",
-                        "name": "tender",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.Tender"
-                        }
+                        "type": "integer",
+                        "description": "ID предложения",
+                        "name": "bidId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешно созданный тендер",
+                        "description": "Статус предложения",
                         "schema": {
-                            "$ref": "#/definitions/models.Tender"
+                            "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Неверные данные для создания тендера",
+                        "description": "Неверный ID предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав для просмотра статуса предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Предложение или пользователь не найдены",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сохранения тендера в базе данных",
+                        "description": "Ошибка сервера",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            }
-        },
-        "/tenders/{tenderId}/edit": {
-            "patch": {
-                "description": "Обновляет данные тендера (имя, описание, тип услуг) по его ID, если пользователь имеет права.",
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Изменяет статус предложения на основании прав автора. Статус может быть изменен на 'CANCELED', но не на 'PUBLISHED' или 'CREATED', так как эти статусы устанавливаются автоматически.",
                 "consumes": [
                     "application/json"
                 ],
@@ -847,61 +1109,76 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Bids"
                 ],
-                "summary": "Редактирование тендера",
+                "summary": "Установка статуса предложения",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID тендера",
-                        "name": "tenderId",
+                        "description": "ID предложения",
+                        "name": "bidId",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Имя пользователя, инициирующего изменение",
-                        "name": "username",
+                        "description": "Новый статус предложения ('CANCELED')",
+                        "name": "status",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Данные для обновления тендера (имя, описание, тип услуг)",
-                        "name": "tender",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "object"
-                        }
+                        "type": "string",
+                        "description": "ETag версии, которую видел клиент",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Обновленный тендер",
+                        "description": "Обновленное предложение",
                         "schema": {
-                            "$ref": "#/definitions/models.Tender"
+                            "$ref": "#/definitions/models.Bid"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия объекта после изменения"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неверные данные или ID тендера",
+                        "description": "Неверный статус или ID предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "403": {
-                        "description": "Нет прав на редактирование тендера",
+                        "description": "Нет прав для изменения статуса предложения",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Тендер или пользователь не найдены",
+                        "description": "Предложение, тендер или пользователь не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Версия устарела, объект изменен другим пользователем",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка обновления тендера",
+                        "description": "Ошибка обновления статуса",
                         "schema": {
                             "type": "string"
                         }
@@ -909,9 +1186,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/tenders/{tenderId}/rollback/{version}": {
+        "/bids/{bidId}/submit_decision": {
             "put": {
-                "description": "Откатывает тендер к указанной версии на основании прав пользователя и статуса тендера. Откат невозможен, если тендер уже закрыт.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет решение (\"Approved\" или \"Rejected\") по предложению на основании прав пользователя. Проверяет наличие кворума для публикации предложения, тендер закрывается, когда набрано число победителей MaxWinners.",
                 "consumes": [
                     "application/json"
                 ],
@@ -919,59 +1201,82 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Bids"
                 ],
-                "summary": "Откат тендера к версии",
+                "summary": "Добавление решения по предложению",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID тендера",
-                        "name": "tenderId",
+                        "description": "ID предложения",
+                        "name": "bidId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Версия тендера, к которой необходимо откатиться",
-                        "name": "version",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Решение по предложению ('Approved' или 'Rejected')",
+                        "name": "decision",
+                        "in": "query",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Имя пользователя, инициирующего откат",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
+                        "description": "ETag версии, которую видел клиент",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Откатанный тендер",
+                        "description": "Обновленное предложение",
                         "schema": {
-                            "$ref": "#/definitions/models.Tender"
+                            "$ref": "#/definitions/models.Bid"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия объекта после изменения"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неправильный ID тендера или версия",
+                        "description": "Неверное решение или ID предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "403": {
-                        "description": "Нет прав на откат тендера",
+                        "description": "Нет прав для принятия решения по предложению",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Тендер или версия не найдены",
+                        "description": "Пользователь, предложение или тендер не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Решение по данному предложению уже было принято",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Версия устарела, объект изменен другим пользователем",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сохранения откатанного тендера",
+                        "description": "Ошибка сохранения решения или публикации предложения",
                         "schema": {
                             "type": "string"
                         }
@@ -979,241 +1284,5314 @@ const docTemplate = `{
                 }
             }
         },
-        "/tenders/{tenderId}/status": {
+        "/bids/{bidId}/versions": {
             "get": {
-                "description": "Возвращает статус тендера, если пользователь имеет права на просмотр статуса.",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Возвращает все сохраненные версии предложения по возрастанию номера со временем и автором изменения. Доступно автору предложения и ответственным за тендер, для закрытого тендера — после вскрытия предложений.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Versions"
                 ],
-                "summary": "Получение статуса тендера",
+                "summary": "История версий предложения",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID тендера",
-                        "name": "tenderId",
+                        "description": "ID предложения",
+                        "name": "bidId",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Имя пользователя, запрашивающего статус тендера",
-                        "name": "username",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Статус тендера",
+                        "description": "Версии предложения",
                         "schema": {
-                            "type": "string"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BidVersion"
+                            }
                         }
                     },
                     "400": {
-                        "description": "Неправильный ID тендера или никнейм не введен",
+                        "description": "Неверный ID предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "403": {
-                        "description": "Пользователь не является ответственным за тендер",
+                        "description": "Нет прав на просмотр предложения",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Тендер или пользователь не найдены",
+                        "description": "Предложение или тендер не найдены",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка сервера",
+                        "description": "Ошибка загрузки версий",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Позволяет изменить статус тендера на \"publish\" или \"close\", если пользователь имеет права доступа.",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/bids/{bidId}/versions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Возвращает поля, которые отличаются между версиями from и to, со значениями в каждой из них.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Tenders"
+                    "Versions"
                 ],
-                "summary": "Изменение статуса тендера",
+                "summary": "Сравнение версий предложения",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "ID тендера",
-                        "name": "tenderId",
+                        "description": "ID предложения",
+                        "name": "bidId",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Имя пользователя, изменяющего статус",
-                        "name": "username",
+                        "type": "integer",
+                        "description": "Исходная версия",
+                        "name": "from",
                         "in": "query",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "Новый статус тендера ('publish' или 'close')",
-                        "name": "status",
+                        "type": "integer",
+                        "description": "Версия для сравнения",
+                        "name": "to",
                         "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Успешно обновленный тендер",
+                        "description": "Разница версий",
                         "schema": {
-                            "$ref": "#/definitions/models.Tender"
+                            "$ref": "#/definitions/models.VersionDiff"
                         }
                     },
                     "400": {
-                        "description": "Неверный ID тендера или неправильный статус",
+                        "description": "Неверный ID предложения или номер версии",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "403": {
-                        "description": "Нет прав для изменения статуса",
+                        "description": "Нет прав на просмотр предложения",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Тендер или пользователь не найдены",
+                        "description": "Предложение, тендер или версия не найдены",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "500": {
-                        "description": "Ошибка обновления тендера",
+                        "description": "Ошибка загрузки версий",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AuthorBidsType": {
-            "type": "string",
-            "enum": [
-                "USER",
-                "ORGANIZATION"
-            ],
-            "x-enum-varnames": [
-                "USER",
-                "ORGANIZATION"
-            ]
         },
-        "models.Bid": {
-            "type": "object",
-            "properties": {
-                "author_id": {
-                    "type": "integer"
-                },
-                "author_type": {
-                    "$ref": "#/definitions/models.AuthorBidsType"
-                },
-                "created_at": {
-                    "type": "string"
-                },
-                "description": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "integer"
-                },
+        "/bids/{tenderId}/list": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список предложений для указанного тендера, если пользователь имеет право на просмотр.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bids"
+                ],
+                "summary": "Получение предложений по TenderID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID лота для фильтрации предложений",
+                        "name": "lotId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список предложений (для закрытого тендера до вскрытия - объект models.SealedBids)",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Bid"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный тендер ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на получение списка предложений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или пользователь не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка получения предложений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bids/{tenderId}/ranking": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает предложения аукциона по возрастанию цены, текущий раунд и время его окончания. Ответственные за тендер видят ID всех предложений, участники аукциона — только свои.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auctions"
+                ],
+                "summary": "Рейтинг аукциона",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Рейтинг аукциона",
+                        "schema": {
+                            "$ref": "#/definitions/models.AuctionRanking"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или тендер не является аукционом",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на просмотр рейтинга",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки предложений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bids/{tenderId}/reviews": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает отзывы по предложениям автора (authorUsername), связанным с тендером, если авторизованный пользователь имеет права доступа.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bids"
+                ],
+                "summary": "Получение отзывов по предложениям пользователя",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Имя пользователя, автора предложений",
+                        "name": "authorUsername",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список отзывов по предложениям (для закрытого тендера до вскрытия - объект models.SealedBids)",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BidFeedback"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или отсутствует authorUsername",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет доступа к просмотру обратной связи",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или пользователь не найден, или нет предложений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки данных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Возвращает категории услуг, на которые ссылается поле ServiceType тендера. С параметром parentCode возвращает только прямые подкатегории, с пустым parentCode — категории верхнего уровня.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Справочник категорий услуг",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Код родительской категории",
+                        "name": "parentCode",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список категорий",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ServiceCategory"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки категорий",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список всех сотрудников.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Получение списка сотрудников",
+                "responses": {
+                    "200": {
+                        "description": "Список сотрудников",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Employee"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки сотрудников",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/new": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает нового сотрудника с уникальным именем пользователя и паролем для входа. Заводить сотрудников могут владельцы (OWNER) организаций и администраторы из ADMIN_USERNAMES. Первый сотрудник создается в базе напрямую: INSERT INTO employee (username, password_hash) VALUES ('admin', crypt('secret', gen_salt('bf'))) (расширение pgcrypto), его имя указывается в ADMIN_USERNAMES.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Создание сотрудника",
+                "parameters": [
+                    {
+                        "description": "Данные сотрудника",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.EmployeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданный сотрудник",
+                        "schema": {
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на создание сотрудников",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка создания сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает данные сотрудника по его ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Получение сотрудника",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника",
+                        "name": "employeeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сотрудник",
+                        "schema": {
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Сотрудник не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет сотрудника и его связи с организациями. Сотрудник может удалить только себя. Последний владелец организации сначала должен передать владение другому ответственному.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Удаление сотрудника",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника",
+                        "name": "employeeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удаленный сотрудник",
+                        "schema": {
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на удаление сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Сотрудник не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Сотрудник последний владелец организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка удаления сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/employees/{employeeId}/edit": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет имя пользователя, имя, фамилию или пароль. Сотрудник может изменять только свои данные.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Employees"
+                ],
+                "summary": "Редактирование сотрудника",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника",
+                        "name": "employeeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления сотрудника",
+                        "name": "employee",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.EmployeeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленный сотрудник",
+                        "schema": {
+                            "$ref": "#/definitions/models.Employee"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные или ID сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Сотрудник не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка обновления сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список всех организаций.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Получение списка организаций",
+                "responses": {
+                    "200": {
+                        "description": "Список организаций",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Organization"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки организаций",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/new": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает организацию, создатель автоматически становится ее владельцем (роль OWNER). Правило кворума quorumRule (FIXED, PERCENT, MAJORITY, UNANIMOUS) и quorumValue по умолчанию — FIXED 3.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Создание организации",
+                "parameters": [
+                    {
+                        "description": "Данные организации",
+                        "name": "organization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданная организация",
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка создания организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{organizationId}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает данные организации по ее ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Получение организации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Организация",
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет организацию вместе со списком ответственных. Доступно только владельцам, организацию с тендерами удалить нельзя.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Удаление организации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удаленная организация",
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на удаление организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "У организации есть тендеры",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка удаления организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{organizationId}/edit": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет название, описание, тип организации или правило кворума по умолчанию (действует для новых тендеров). Доступно только владельцам организации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Редактирование организации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления организации",
+                        "name": "organization",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OrganizationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленная организация",
+                        "schema": {
+                            "$ref": "#/definitions/models.Organization"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные или ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка обновления организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{organizationId}/responsibles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список сотрудников, ответственных за организацию, вместе с их ролями.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Получение ответственных за организацию",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список ответственных",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.ResponsibleResponse"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Организация не найдена",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки ответственных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Назначает сотрудника ответственным за организацию с указанной ролью (по умолчанию VIEWER). Доступно только владельцам организации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Добавление ответственного",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Имя пользователя и роль нового ответственного",
+                        "name": "responsible",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.ResponsibleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданная связь",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationResponsible"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные или ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение ответственных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Организация или пользователь не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Сотрудник уже ответственен за организацию",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения ответственного",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/organizations/{organizationId}/responsibles/{employeeId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Снимает с сотрудника ответственность за организацию. Доступно только владельцам организации, последнего владельца удалить нельзя.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Удаление ответственного",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника",
+                        "name": "employeeId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удаленная связь",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationResponsible"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации или сотрудника",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение ответственных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Организация или связь не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Нельзя удалить последнего владельца",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка удаления ответственного",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Меняет роль ответственного. Доступно только владельцам организации, последнего владельца понизить нельзя.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Organizations"
+                ],
+                "summary": "Изменение роли ответственного",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID сотрудника",
+                        "name": "employeeId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Новая роль",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленная связь",
+                        "schema": {
+                            "$ref": "#/definitions/models.OrganizationResponsible"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверная роль или ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение ответственных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Организация или связь не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Нельзя понизить последнего владельца",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка обновления роли",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/ping": {
+            "get": {
+                "description": "Возвращает \"ok\", если сервер работает.",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Проверка состояния сервера",
+                "responses": {
+                    "200": {
+                        "description": "ok",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders": {
+            "get": {
+                "description": "Без фильтра status возвращает только опубликованные тендеры, а ответственным — еще и черновики (CREATED) их организаций. Черновики чужих организаций не видны даже при явном фильтре. Тендеры с видимостью INVITE_ONLY видны только ответственным за них и приглашенным. Фильтры status и serviceType принимают несколько значений через запятую или повтором параметра, категория услуг включает подкатегории.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Получение списка тендеров",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Статусы тендеров (PUBLISHED, CREATED, CLOSED, CANCELLED)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Созданы не раньше (RFC3339 или YYYY-MM-DD)",
+                        "name": "createdFrom",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Созданы не позже (RFC3339 или YYYY-MM-DD включительно)",
+                        "name": "createdTo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Коды категорий услуг для фильтрации тендеров",
+                        "name": "serviceType",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список тендеров",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tender"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный фильтр",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки тендеров",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/my": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает список всех тендеров, созданных авторизованным пользователем.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Получение тендеров пользователя",
+                "responses": {
+                    "200": {
+                        "description": "Список тендеров пользователя",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Tender"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка поиска тендеров",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/new": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает новый тендер, декодируя данные из тела запроса и сохраняя их в базе данных. Если правило кворума QuorumRule не задано, берется правило организации.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Создание нового тендера",
+                "parameters": [
+                    {
+                        "description": "Данные для создания тендера",
+                        "name": "tender",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешно созданный тендер",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные для создания тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения тендера в базе данных",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/report": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает количество тендеров организации по статусам и итогам: присужденные, закрытые без победителя, истекшие с предложениями и без них, отмененные.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Отчет по тендерам организации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отчет по тендерам",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderReport"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на просмотр тендеров организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка построения отчета",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/templates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает шаблоны тендеров организации. Доступно ответственным за организацию.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Список шаблонов организации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID организации",
+                        "name": "organizationId",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список шаблонов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TenderTemplate"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на просмотр тендеров организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки шаблонов",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/templates/{templateId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет шаблон организации. Тендеры, созданные по шаблону, не меняются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Удаление шаблона",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID шаблона",
+                        "name": "templateId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удаленный шаблон",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID шаблона",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав создавать тендеры организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка удаления шаблона",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/templates/{templateId}/new": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает новый тендер в статусе CREATED с настройками, лотами, критериями и приглашениями из шаблона. Создателем считается авторизованный пользователь.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Создание тендера по шаблону",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID шаблона",
+                        "name": "templateId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданный тендер",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID шаблона или категория услуг отсутствует в справочнике",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав создавать тендеры организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Шаблон не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка создания тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/amendments": {
+            "get": {
+                "description": "Возвращает изменения условий, внесенные после публикации тендера: номер, версию тендера, пояснение и список измененных полей.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Amendments"
+                ],
+                "summary": "Изменения условий тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список изменений",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TenderAmendment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки изменений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/approval": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает статус текущего раунда согласования, число нужных и полученных одобрений и все решения с комментариями по всем раундам.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approvals"
+                ],
+                "summary": "Состояние согласования тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Состояние согласования",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderApproval"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на просмотр тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки решений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Согласующий одобряет (APPROVE) или отклоняет (REJECT) тендер, отклонение требует комментария. Автор отправки не участвует в согласовании, каждый согласующий принимает одно решение за раунд. Отклонение завершает раунд, после исправлений тендер отправляется на согласование заново.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approvals"
+                ],
+                "summary": "Решение по согласованию тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Решение и комментарий",
+                        "name": "signOff",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.SignOffRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Состояние согласования",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderApproval"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID, решение или тендер не на согласовании",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на согласование или согласование собственной отправки",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Решение в этом раунде уже принято",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения решения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Начинает новый раунд согласования тендера в статусе CREATED. Число одобрений берется из настройки организации signOffRequired, согласовывать могут только другие ответственные с ролью OWNER, PROCUREMENT_MANAGER или APPROVER. Пока согласование не завершено, тендер нельзя опубликовать.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Approvals"
+                ],
+                "summary": "Отправка тендера на согласование",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Состояние согласования",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderApproval"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID, статус тендера, согласование не требуется или недостаточно согласующих",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Тендер уже на согласовании или согласован",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/attachments": {
+            "get": {
+                "description": "Возвращает метаданные файлов тендера всем, кто видит тендер (в том числе без авторизации для опубликованных публичных тендеров).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Список файлов тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список файлов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Attachment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки файлов",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Принимает multipart/form-data с файлом в поле file. Файл проверяется по размеру и типу, для него считается SHA-256. Загружать файлы могут ответственные, которым разрешено изменять тендер, пока тендер не завершен.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Attachments"
+                ],
+                "summary": "Загрузка файла к тендеру",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Файл",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Сохраненное вложение",
+                        "schema": {
+                            "$ref": "#/definitions/models.Attachment"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный файл, ID или статус тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Файл слишком большой",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения файла",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/clone": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Создает новый тендер в статусе CREATED с версией 1 и теми же настройками, лотами, критериями и приглашениями. Копировать можно тендер в любом статусе, в том числе закрытый. Сроки подачи и публикации не копируются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Копирование тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданный тендер",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или категория услуг отсутствует в справочнике",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав создавать тендеры организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка создания тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/criteria": {
+            "get": {
+                "description": "Возвращает взвешенные критерии, по которым оцениваются предложения тендера.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Получение критериев оценки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список критериев",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.EvaluationCriterion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки критериев",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет в тендер в статусе CREATED критерий оценки предложений (цена, сроки поставки, опыт и т.д.) с положительным весом.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Создание критерия оценки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные критерия",
+                        "name": "criterion",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CriterionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданный критерий",
+                        "schema": {
+                            "$ref": "#/definitions/models.EvaluationCriterion"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные критерия, ID или статус тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка создания критерия",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/criteria/{criterionId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет критерий, пока тендер в статусе CREATED.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Удаление критерия оценки",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID критерия",
+                        "name": "criterionId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удаленный критерий",
+                        "schema": {
+                            "$ref": "#/definitions/models.EvaluationCriterion"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или статус тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или критерий не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка удаления критерия",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/edit": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет данные тендера (имя, описание, тип услуг, срок подачи предложений submissionDeadline, максимальную цену budget, валюту currency, режим закрытых предложений sealed, видимость visibility, тип type и параметры аукциона auctionStep, auctionRoundSeconds, auctionExtensionSeconds, число победителей maxWinners, правило кворума quorumRule и quorumValue до публикации) по его ID, если пользователь имеет права. Изменение условий опубликованного тендера записывается как официальное изменение с пояснением changeNote, а поданные предложения помечаются как требующие подтверждения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Редактирование тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления тендера (имя, описание, тип услуг, submissionDeadline, budget, currency, sealed, visibility, type, auctionStep, auctionRoundSeconds, auctionExtensionSeconds; для опубликованного тендера обязателен changeNote)",
+                        "name": "tender",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии, которую видел клиент",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленный тендер",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия объекта после изменения"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные или ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на редактирование тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или пользователь не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Версия устарела, объект изменен другим пользователем",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка обновления тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/evaluation": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает действующие предложения тендера по убыванию итоговой оценки: средняя оценка по каждому критерию, взвешенная по весам критериев. Критерий без оценок считается как 0.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Рейтинг предложений по критериям",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID лота для рейтинга внутри лота",
+                        "name": "lotId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Рейтинг предложений",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BidEvaluation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или лота, либо предложения еще не вскрыты",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на просмотр предложений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка расчета рейтинга",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/evaluation/award": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Принимает лучшее по рейтингу критериев предложение из еще не победивших тем же путем, что и кворум голосования: тендер закрывается, когда набрано число победителей MaxWinners. Для тендера с лотами присуждается указанный лот.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Evaluation"
+                ],
+                "summary": "Присуждение по рейтингу",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID лота (обязателен для тендера с лотами)",
+                        "name": "lotId",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Принятое предложение",
+                        "schema": {
+                            "$ref": "#/definitions/models.Bid"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID, статус тендера или нет оцененных предложений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на закрытие тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или лот не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Предложение уже принято или отменено параллельным запросом",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка присуждения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/invitations": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает организации и сотрудников, приглашенных к участию в тендере. Доступно ответственным за тендер.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Список приглашенных",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список приглашений",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TenderInvitation"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на просмотр тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки приглашений",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет организацию или сотрудника в список приглашенных. Тендер с видимостью INVITE_ONLY видят и принимают от них предложения только приглашенные.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Приглашение к тендеру",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Организация (organizationId) или сотрудник (username)",
+                        "name": "invitation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.InvitationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданное приглашение",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderInvitation"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные приглашения, ID или статус тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер, организация или сотрудник не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Приглашение уже существует",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения приглашения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/invitations/{invitationId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет организацию или сотрудника из списка приглашенных. Уже поданные предложения остаются.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Invitations"
+                ],
+                "summary": "Отзыв приглашения",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID приглашения",
+                        "name": "invitationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отозванное приглашение",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderInvitation"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или приглашение не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка удаления приглашения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/lots": {
+            "get": {
+                "description": "Возвращает лоты тендера с их статусами и победившими предложениями.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lots"
+                ],
+                "summary": "Получение лотов тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список лотов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Lot"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки лотов",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Добавляет в тендер в статусе CREATED независимый лот со своей максимальной ценой. Предложения по тендеру с лотами подаются на конкретный лот.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lots"
+                ],
+                "summary": "Создание лота",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные лота",
+                        "name": "lot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.LotRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданный лот",
+                        "schema": {
+                            "$ref": "#/definitions/models.Lot"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные лота, ID или статус тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка создания лота",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/lots/{lotId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Удаляет лот, пока тендер в статусе CREATED. После публикации лот можно только отменить.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lots"
+                ],
+                "summary": "Удаление лота",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID лота",
+                        "name": "lotId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Удаленный лот",
+                        "schema": {
+                            "$ref": "#/definitions/models.Lot"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID или статус тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или лот не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "По лоту есть предложения",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка удаления лота",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Обновляет название, описание или максимальную цену лота (null снимает цену), пока тендер в статусе CREATED.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lots"
+                ],
+                "summary": "Редактирование лота",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID лота",
+                        "name": "lotId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Данные для обновления лота (name, description, budget)",
+                        "name": "lot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Обновленный лот",
+                        "schema": {
+                            "$ref": "#/definitions/models.Lot"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверные данные лота, ID или статус тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на изменение тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или лот не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка обновления лота",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/lots/{lotId}/cancel": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Отменяет открытый лот и все его незавершенные предложения. Когда по всем лотам принято решение (присуждены или отменены), тендер закрывается.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lots"
+                ],
+                "summary": "Отмена лота",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID лота",
+                        "name": "lotId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Отмененный лот",
+                        "schema": {
+                            "$ref": "#/definitions/models.Lot"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID, статус тендера или лота",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на закрытие тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или лот не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка отмены лота",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/questions": {
+            "get": {
+                "description": "Всем, кто видит тендер, возвращает вопросы с ответами, без автора вопроса. Участник дополнительно видит свои неотвеченные вопросы, ответственные за тендер — все вопросы и их авторов.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Вопросы и ответы по тендеру",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Список вопросов",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.QuestionView"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки вопросов",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Участник задает вопрос по условиям опубликованного тендера. Автор вопроса виден только ответственным за тендер.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Вопрос по тендеру",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст вопроса",
+                        "name": "question",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.QuestionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданный вопрос",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuestionView"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID, текст вопроса или тендер не опубликован",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Ответственные за тендер не задают вопросы по нему",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения вопроса",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/questions/{questionId}/answer": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ответственный за тендер отвечает на вопрос участника, повторный вызов исправляет ответ. Ответ становится виден всем, кто видит тендер.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Questions"
+                ],
+                "summary": "Ответ на вопрос по тендеру",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID вопроса",
+                        "name": "questionId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Текст ответа",
+                        "name": "answer",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.AnswerRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Вопрос с ответом",
+                        "schema": {
+                            "$ref": "#/definitions/models.QuestionView"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID, текст ответа или тендер не опубликован",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав отвечать на вопросы",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или вопрос не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения ответа",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/rollback/{version}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Откатывает тендер к указанной версии на основании прав пользователя и статуса тендера. Откат невозможен, если тендер уже закрыт. Статус тендера откатом не меняется. Восстановленные условия проверяются так же, как при редактировании: срок подачи предложений должен быть в будущем, у закрытого (sealed) тендера он обязателен, у аукциона не задается.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Откат тендера к версии",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Версия тендера, к которой необходимо откатиться",
+                        "name": "version",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии, которую видел клиент",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Откатанный тендер",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия объекта после изменения"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неправильный ID тендера или версия, неверные восстановленные условия",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на откат тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или версия не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Версия устарела, объект изменен другим пользователем",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения откатанного тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/schedule": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Задает время, в которое тендер в статусе CREATED будет автоматически опубликован. Повторный вызов переносит публикацию. Если организация требует согласования, запланировать можно только согласованный тендер.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Планирование публикации тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Время публикации в формате RFC3339",
+                        "name": "schedule",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.PublishScheduleRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии, которую видел клиент",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тендер с запланированной публикацией",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия объекта"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера, время публикации, статус тендера или тендер не прошел согласование",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на публикацию тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Версия устарела, объект изменен другим пользователем",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Снимает запланированную публикацию, тендер остается в статусе CREATED.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Отмена запланированной публикации",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии, которую видел клиент",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Тендер без запланированной публикации",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия объекта"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера, статус тендера или публикация не запланирована",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на публикацию тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Версия устарела, объект изменен другим пользователем",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/status": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает статус тендера, если пользователь имеет права на просмотр статуса.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Получение статуса тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Статус тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Неправильный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Пользователь не является ответственным за тендер",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или пользователь не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сервера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Позволяет опубликовать тендер (\"publish\"), закрыть его (\"close\") или отменить (\"cancel\") с обязательной причиной reason, если пользователь имеет права доступа. Отмена переводит тендер из CREATED или PUBLISHED в CANCELLED и отменяет все незавершенные предложения.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Tenders"
+                ],
+                "summary": "Изменение статуса тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Новый статус тендера ('publish', 'close' или 'cancel')",
+                        "name": "status",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Причина отмены, обязательна для 'cancel'",
+                        "name": "reason",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag версии, которую видел клиент",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Успешно обновленный тендер",
+                        "schema": {
+                            "$ref": "#/definitions/models.Tender"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Версия объекта после изменения"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера, неправильный статус или тендер не прошел согласование",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав для изменения статуса",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или пользователь не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "412": {
+                        "description": "Версия устарела, объект изменен другим пользователем",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка обновления тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/template": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Сохраняет название, описание, тип услуг, бюджет, режимы, параметры аукциона, число победителей и правило кворума, лоты, критерии оценки и приглашения тендера в шаблон его организации. Сроки, статус и история версий в шаблон не попадают.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Сохранение тендера как шаблона",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Название шаблона",
+                        "name": "template",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Созданный шаблон",
+                        "schema": {
+                            "$ref": "#/definitions/models.TenderTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверное название шаблона или ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав создавать тендеры организации",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка сохранения шаблона",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/versions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает все сохраненные версии тендера по возрастанию номера: время создания версии CreatedAt и автора изменения ChangedBy (пусто для изменений, сделанных системой, например публикации по расписанию или закрытия по кворуму).",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Versions"
+                ],
+                "summary": "История версий тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Версии тендера",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TenderVersion"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на просмотр тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер не найден",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки версий",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/tenders/{tenderId}/versions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Возвращает поля, которые отличаются между версиями from и to, со значениями в каждой из них. Помогает проверить изменения перед откатом.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Versions"
+                ],
+                "summary": "Сравнение версий тендера",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "ID тендера",
+                        "name": "tenderId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Исходная версия",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Версия для сравнения",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Разница версий",
+                        "schema": {
+                            "$ref": "#/definitions/models.VersionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Неверный ID тендера или номер версии",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Необходима авторизация",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Нет прав на просмотр тендера",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Тендер или версия не найдены",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Ошибка загрузки версий",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.AnswerRequest": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.AwardRequest": {
+            "type": "object",
+            "properties": {
+                "quantity": {
+                    "type": "string"
+                },
+                "share": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CriterionRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.CriterionScore": {
+            "type": "object",
+            "properties": {
+                "criterionId": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.EmployeeRequest": {
+            "type": "object",
+            "properties": {
+                "firstName": {
+                    "type": "string"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.InvitationRequest": {
+            "type": "object",
+            "properties": {
+                "organizationId": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LoginRequest": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LotRequest": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.LowerPriceRequest": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.OrganizationRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quorumRule": {
+                    "$ref": "#/definitions/models.QuorumRule"
+                },
+                "quorumValue": {
+                    "type": "integer"
+                },
+                "signOffRequired": {
+                    "description": "Сколько других ответственных должны согласовать тендер перед публикацией",
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.OrganizationType"
+                }
+            }
+        },
+        "handlers.PublishScheduleRequest": {
+            "type": "object",
+            "properties": {
+                "publishAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.QuestionRequest": {
+            "type": "object",
+            "properties": {
+                "question": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ResponsibleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "$ref": "#/definitions/models.ResponsibleRole"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.ResponsibleResponse": {
+            "type": "object",
+            "properties": {
+                "employeeId": {
+                    "type": "integer"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/models.ResponsibleRole"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RoleRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "$ref": "#/definitions/models.ResponsibleRole"
+                }
+            }
+        },
+        "handlers.ScoreRequest": {
+            "type": "object",
+            "properties": {
+                "scores": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.CriterionScore"
+                    }
+                }
+            }
+        },
+        "handlers.SignOffRequest": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "decision": {
+                    "$ref": "#/definitions/models.SignOffDecision"
+                }
+            }
+        },
+        "handlers.TemplateRequest": {
+            "type": "object",
+            "properties": {
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ApprovalStatus": {
+            "type": "string",
+            "enum": [
+                "PENDING",
+                "APPROVED",
+                "REJECTED"
+            ],
+            "x-enum-varnames": [
+                "PENDINGApproval",
+                "APPROVEDApproval",
+                "REJECTEDApproval"
+            ]
+        },
+        "models.Attachment": {
+            "type": "object",
+            "properties": {
+                "bidId": {
+                    "type": "integer"
+                },
+                "contentType": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "fileName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "sha256": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "tenderId": {
+                    "type": "integer"
+                },
+                "uploaderId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AuctionPosition": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "bidId": {
+                    "type": "integer"
+                },
+                "own": {
+                    "type": "boolean"
+                },
+                "place": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuctionRanking": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string"
+                },
+                "positions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AuctionPosition"
+                    }
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "round_ends_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.TenderStatus"
+                },
+                "step": {
+                    "type": "string"
+                },
+                "tenderId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AuthorBidsType": {
+            "type": "string",
+            "enum": [
+                "USER",
+                "ORGANIZATION"
+            ],
+            "x-enum-varnames": [
+                "USER",
+                "ORGANIZATION"
+            ]
+        },
+        "models.Bid": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "author_id": {
+                    "type": "integer"
+                },
+                "author_type": {
+                    "$ref": "#/definitions/models.AuthorBidsType"
+                },
+                "award_quantity": {
+                    "type": "string"
+                },
+                "award_share": {
+                    "description": "Доля (в процентах) и объем поставки, присужденные победившему предложению",
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lotId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "needs_reconfirmation": {
+                    "description": "Условия тендера изменились после подачи, автор должен подтвердить или изменить предложение",
+                    "type": "boolean"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.BidStatus"
+                },
+                "tenderId": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.BidEvaluation": {
+            "type": "object",
+            "properties": {
+                "bidId": {
+                    "type": "integer"
+                },
+                "criteria": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CriterionResult"
+                    }
+                },
+                "lotId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "scored": {
+                    "type": "boolean"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.BidStatus"
+                },
+                "total_score": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BidFeedback": {
+            "type": "object",
+            "properties": {
+                "bidID": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "feedback": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BidScore": {
+            "type": "object",
+            "properties": {
+                "bidId": {
+                    "type": "integer"
+                },
+                "criterionId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "responsibleId": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.BidStatus": {
+            "type": "string",
+            "enum": [
+                "CREATED",
+                "PUBLISHED",
+                "CANCELED",
+                "APPROVED",
+                "REJECTED"
+            ],
+            "x-enum-varnames": [
+                "CREATEDBid",
+                "PUBLISHEDBid",
+                "CANCELED",
+                "APPROVED",
+                "REJECTED"
+            ]
+        },
+        "models.BidVersion": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string"
+                },
+                "author_id": {
+                    "type": "integer"
+                },
+                "author_type": {
+                    "$ref": "#/definitions/models.AuthorBidsType"
+                },
+                "bidId": {
+                    "type": "integer"
+                },
+                "changedBy": {
+                    "description": "Сотрудник, внесший изменение; пусто для изменений, сделанных системой",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.BidStatus"
+                },
+                "tenderId": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CriterionResult": {
+            "type": "object",
+            "properties": {
+                "average_score": {
+                    "type": "string"
+                },
+                "criterionId": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "votes": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Employee": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "firstName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lastName": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.EvaluationCriterion": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "tenderId": {
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Lot": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.LotStatus"
+                },
+                "tenderId": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "winnerBidId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.LotStatus": {
+            "type": "string",
+            "enum": [
+                "OPEN",
+                "AWARDED",
+                "CANCELLED"
+            ],
+            "x-enum-varnames": [
+                "OPENLot",
+                "AWARDEDLot",
+                "CANCELLEDLot"
+            ]
+        },
+        "models.Organization": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quorumRule": {
+                    "description": "Правило кворума по умолчанию для новых тендеров: число голосов для FIXED, процент для PERCENT",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.QuorumRule"
+                        }
+                    ]
+                },
+                "quorumValue": {
+                    "type": "integer"
+                },
+                "signOffRequired": {
+                    "description": "Сколько других ответственных должны согласовать тендер перед публикацией, 0 — без согласования",
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.OrganizationType"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.OrganizationResponsible": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "organizationID": {
+                    "type": "integer"
+                },
+                "role": {
+                    "$ref": "#/definitions/models.ResponsibleRole"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.OrganizationType": {
+            "type": "string",
+            "enum": [
+                "IE",
+                "LLC",
+                "JSC"
+            ],
+            "x-enum-varnames": [
+                "IE",
+                "LLC",
+                "JSC"
+            ]
+        },
+        "models.QuestionView": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "string"
+                },
+                "answered": {
+                    "type": "boolean"
+                },
+                "answered_at": {
+                    "type": "string"
+                },
+                "askerId": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "own": {
+                    "type": "boolean"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "tenderId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.QuorumRule": {
+            "type": "string",
+            "enum": [
+                "FIXED",
+                "PERCENT",
+                "MAJORITY",
+                "UNANIMOUS"
+            ],
+            "x-enum-varnames": [
+                "FIXEDQuorum",
+                "PERCENTQuorum",
+                "MAJORITYQuorum",
+                "UNANIMOUSQuorum"
+            ]
+        },
+        "models.ResponsibleRole": {
+            "type": "string",
+            "enum": [
+                "OWNER",
+                "PROCUREMENT_MANAGER",
+                "APPROVER",
+                "VIEWER"
+            ],
+            "x-enum-varnames": [
+                "OWNER",
+                "PROCUREMENT_MANAGER",
+                "APPROVER",
+                "VIEWER"
+            ]
+        },
+        "models.ServiceCategory": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parentCode": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SignOffDecision": {
+            "type": "string",
+            "enum": [
+                "APPROVE",
+                "REJECT"
+            ],
+            "x-enum-varnames": [
+                "APPROVESignOff",
+                "REJECTSignOff"
+            ]
+        },
+        "models.TemplateCriterion": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TemplateInvitation": {
+            "type": "object",
+            "properties": {
+                "employeeId": {
+                    "type": "integer"
+                },
+                "organizationId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TemplateLot": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Tender": {
+            "type": "object",
+            "properties": {
+                "approvalRound": {
+                    "type": "integer"
+                },
+                "approvalStatus": {
+                    "description": "Согласование перед публикацией: статус, номер раунда, сколько одобрений нужно и кто отправил",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ApprovalStatus"
+                        }
+                    ]
+                },
+                "approvalSubmittedBy": {
+                    "type": "integer"
+                },
+                "approvalsRequired": {
+                    "type": "integer"
+                },
+                "auctionExtensionSeconds": {
+                    "type": "integer"
+                },
+                "auctionRound": {
+                    "description": "Текущее состояние аукциона, заполняется сервером",
+                    "type": "integer"
+                },
+                "auctionRoundBids": {
+                    "type": "integer"
+                },
+                "auctionRoundEndsAt": {
+                    "type": "string"
+                },
+                "auctionRoundSeconds": {
+                    "type": "integer"
+                },
+                "auctionStep": {
+                    "description": "Параметры аукциона на понижение: шаг цены, длительность раунда и окно продления при позднем предложении",
+                    "type": "string"
+                },
+                "budget": {
+                    "type": "string"
+                },
+                "cancellationReason": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "creatorUsername": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "maxWinners": {
+                    "description": "Сколько предложений может победить, тендер закрывается при достижении этого числа",
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
-                "status": {
-                    "$ref": "#/definitions/models.BidStatus"
+                "organizationID": {
+                    "type": "integer"
                 },
-                "tenderId": {
+                "outcome": {
+                    "$ref": "#/definitions/models.TenderOutcome"
+                },
+                "publishAt": {
+                    "type": "string"
+                },
+                "quorumRule": {
+                    "description": "Правило кворума тендера, при создании берется из организации, если не задано явно",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.QuorumRule"
+                        }
+                    ]
+                },
+                "quorumValue": {
                     "type": "integer"
                 },
-                "updated_at": {
+                "sealed": {
+                    "type": "boolean"
+                },
+                "serviceType": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.TenderStatus"
+                },
+                "submissionDeadline": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.TenderType"
+                },
+                "updatedAt": {
                     "type": "string"
                 },
                 "version": {
                     "type": "integer"
+                },
+                "visibility": {
+                    "$ref": "#/definitions/models.TenderVisibility"
                 }
             }
         },
-        "models.BidFeedback": {
+        "models.TenderAmendment": {
             "type": "object",
             "properties": {
-                "bidID": {
+                "authorId": {
                     "type": "integer"
                 },
-                "createdAt": {
+                "changed_fields": {
                     "type": "string"
                 },
-                "feedback": {
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "username": {
+                "note": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "tenderId": {
+                    "type": "integer"
+                },
+                "tender_version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TenderApproval": {
+            "type": "object",
+            "properties": {
+                "approvals": {
+                    "type": "integer"
+                },
+                "required": {
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "signOffs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TenderSignOff"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/models.ApprovalStatus"
+                },
+                "submittedBy": {
+                    "type": "integer"
+                },
+                "tenderId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TenderInvitation": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
+                },
+                "employeeId": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "organizationId": {
+                    "type": "integer"
+                },
+                "tenderId": {
+                    "type": "integer"
                 }
             }
         },
-        "models.BidStatus": {
+        "models.TenderOutcome": {
+            "type": "string",
+            "enum": [
+                "AWARDED",
+                "NOT_AWARDED",
+                "EXPIRED",
+                "EXPIRED_WITHOUT_BIDS",
+                "CANCELLED"
+            ],
+            "x-enum-varnames": [
+                "AWARDEDOutcome",
+                "NOTAWARDEDOutcome",
+                "EXPIREDOutcome",
+                "EXPIREDNoBidsOutcome",
+                "CANCELLEDOutcome"
+            ]
+        },
+        "models.TenderReport": {
+            "type": "object",
+            "properties": {
+                "awarded": {
+                    "type": "integer"
+                },
+                "cancelled": {
+                    "type": "integer"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "expired": {
+                    "type": "integer"
+                },
+                "expired_without_bids": {
+                    "type": "integer"
+                },
+                "not_awarded": {
+                    "type": "integer"
+                },
+                "organizationId": {
+                    "type": "integer"
+                },
+                "published": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TenderSignOff": {
+            "type": "object",
+            "properties": {
+                "comment": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "decision": {
+                    "$ref": "#/definitions/models.SignOffDecision"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reviewerId": {
+                    "type": "integer"
+                },
+                "round": {
+                    "type": "integer"
+                },
+                "tenderId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.TenderStatus": {
             "type": "string",
             "enum": [
                 "CREATED",
                 "PUBLISHED",
-                "CANCELED",
-                "APPROVED",
-                "REJECTED"
+                "CLOSED",
+                "CANCELLED"
             ],
             "x-enum-varnames": [
-                "CREATEDBid",
-                "PUBLISHEDBid",
-                "CANCELED",
-                "APPROVED",
-                "REJECTED"
+                "CREATED",
+                "PUBLISHED",
+                "CLOSED",
+                "CANCELLED"
             ]
         },
-        "models.Tender": {
+        "models.TenderTemplate": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "auctionExtensionSeconds": {
+                    "type": "integer"
+                },
+                "auctionRoundSeconds": {
+                    "type": "integer"
+                },
+                "auctionStep": {
+                    "type": "string"
+                },
+                "budget": {
+                    "type": "string"
+                },
+                "created_at": {
                     "type": "string"
                 },
                 "creatorUsername": {
                     "type": "string"
                 },
+                "criteria": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemplateCriterion"
+                    }
+                },
+                "currency": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
+                "invitations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemplateInvitation"
+                    }
+                },
+                "lots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TemplateLot"
+                    }
+                },
+                "maxWinners": {
+                    "type": "integer"
+                },
                 "name": {
+                    "description": "Настройки создаваемого тендера",
                     "type": "string"
                 },
-                "organizationID": {
+                "organizationId": {
+                    "type": "integer"
+                },
+                "quorumRule": {
+                    "$ref": "#/definitions/models.QuorumRule"
+                },
+                "quorumValue": {
+                    "type": "integer"
+                },
+                "sealed": {
+                    "type": "boolean"
+                },
+                "serviceType": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.TenderType"
+                },
+                "visibility": {
+                    "$ref": "#/definitions/models.TenderVisibility"
+                }
+            }
+        },
+        "models.TenderType": {
+            "type": "string",
+            "enum": [
+                "STANDARD",
+                "AUCTION"
+            ],
+            "x-enum-varnames": [
+                "STANDARD",
+                "AUCTION"
+            ]
+        },
+        "models.TenderVersion": {
+            "type": "object",
+            "properties": {
+                "budget": {
+                    "type": "string"
+                },
+                "cancellationReason": {
+                    "type": "string"
+                },
+                "changedBy": {
+                    "description": "Сотрудник, внесший изменение; пусто для изменений, сделанных системой",
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quorumRule": {
+                    "$ref": "#/definitions/models.QuorumRule"
+                },
+                "quorumValue": {
                     "type": "integer"
                 },
                 "serviceType": {
@@ -1222,26 +6600,62 @@ const docTemplate = `{
                 "status": {
                     "$ref": "#/definitions/models.TenderStatus"
                 },
-                "updatedAt": {
+                "submissionDeadline": {
                     "type": "string"
                 },
+                "tenderID": {
+                    "type": "integer"
+                },
                 "version": {
                     "type": "integer"
                 }
             }
         },
-        "models.TenderStatus": {
+        "models.TenderVisibility": {
             "type": "string",
             "enum": [
-                "CREATED",
-                "PUBLISHED",
-                "CLOSED"
+                "PUBLIC",
+                "INVITE_ONLY"
             ],
             "x-enum-varnames": [
-                "CREATED",
-                "PUBLISHED",
-                "CLOSED"
+                "PUBLICVisibility",
+                "INVITEONLYVisibility"
             ]
+        },
+        "models.VersionChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {},
+                "to": {}
+            }
+        },
+        "models.VersionDiff": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VersionChange"
+                    }
+                },
+                "fromVersion": {
+                    "type": "integer"
+                },
+                "toVersion": {
+                    "type": "integer"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "Токен в формате \"Bearer \u003ctoken\u003e\", выдается ручкой /auth/login",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
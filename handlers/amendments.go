@@ -0,0 +1,206 @@
+package handlers
+
+import (
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"strings"
+	"testAvito/middleware"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// GetTenderAmendmentsHandler возвращает официальные изменения условий тендера.
+// @Summary Изменения условий тендера
+// @Description Возвращает изменения условий, внесенные после публикации тендера: номер, версию тендера, пояснение и список измененных полей.
+// @Tags Amendments
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Success 200 {array} models.TenderAmendment "Список изменений"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка загрузки изменений"
+// @Router /tenders/{tenderId}/amendments [get]
+func GetTenderAmendmentsHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := strconv.Atoi(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "Неверный тендер ID", http.StatusBadRequest)
+		return
+	}
+
+	var tender models.Tender
+	if err := utils.DB.First(&tender, tenderID).Error; err != nil {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return
+	}
+	var viewer *models.Employee
+	if employee, ok := middleware.EmployeeFromContext(r.Context()); ok {
+		viewer = &employee
+	}
+	if !canViewTender(tender, viewer) {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return
+	}
+
+	var amendments []models.TenderAmendment
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Order("number").Find(&amendments).Error; err != nil {
+		http.Error(w, "Ошибка загрузки изменений", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, amendments)
+}
+
+// ReconfirmBidHandler подтверждает предложение после изменения условий тендера.
+// @Summary Подтверждение предложения
+// @Description Автор подтверждает, что предложение остается в силе на измененных условиях тендера, пока идет прием предложений. Сумма предложения проверяется по новой максимальной цене тендера или лота; если она больше не подходит, предложение нужно отредактировать через edit.
+// @Tags Amendments
+// @Produce  json
+// @Param bidId path int true "ID предложения"
+// @Security BearerAuth
+// @Success 200 {object} models.Bid "Подтвержденное предложение"
+// @Failure 400 {string} string "Неверный ID, прием предложений завершен, предложение не требует подтверждения или не укладывается в новые условия"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав для изменения предложения"
+// @Failure 404 {string} string "Предложение или тендер не найдены"
+// @Failure 500 {string} string "Ошибка сохранения предложения"
+// @Router /bids/{bidId}/reconfirm [put]
+func ReconfirmBidHandler(w http.ResponseWriter, r *http.Request) {
+	bidId, err := strconv.Atoi(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "Неверный ID предложения", http.StatusBadRequest)
+		return
+	}
+
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	// Тендер и предложение блокируются до конца транзакции, как и при других изменениях предложения
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	bid, tender, err := lockBidWithTender(tx, uint(bidId))
+	if err != nil {
+		writeLockError(w, err)
+		return
+	}
+
+	switch bid.AuthorType {
+	case models.USER:
+		if bid.AuthorID != employee.ID {
+			http.Error(w, "Только автор предложения может подтвердить его", http.StatusForbidden)
+			return
+		}
+
+	case models.ORGANIZATION:
+		if !checkPermission(w, bid.AuthorID, employee.ID, validators.ActionManageBid, "Только члены организации могут подтвердить предложение") {
+			return
+		}
+	default:
+		http.Error(w, "Неверный тип автора предложения", http.StatusBadRequest)
+		return
+	}
+
+	if !bid.NeedsReconfirmation {
+		http.Error(w, "Предложение не требует подтверждения", http.StatusBadRequest)
+		return
+	}
+	if !submissionOpen(tender) {
+		http.Error(w, "Тендер уже не принимает предложения", http.StatusBadRequest)
+		return
+	}
+
+	// Подтверждается предложение только если оно укладывается в новые условия, иначе его нужно изменить
+	lot, err := bidLot(tender, bid.LotID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckLotBidAmount(bid.Amount, lot, tender); err != nil {
+		change := "edit"
+		if tender.Type == models.AUCTION {
+			change = "lower_price"
+		}
+		http.Error(w, err.Error()+". Измените предложение через "+change, http.StatusBadRequest)
+		return
+	}
+
+	bid.NeedsReconfirmation = false
+	if err := tx.Save(&bid).Error; err != nil {
+		http.Error(w, "Ошибка сохранения предложения", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка сохранения предложения", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, bid)
+}
+
+// Записывает официальное изменение условий и помечает поданные предложения как требующие подтверждения
+func recordAmendment(tx *gorm.DB, tender models.Tender, authorID uint, note string, changed []string) error {
+	var count int64
+	if err := tx.Model(&models.TenderAmendment{}).Where("tender_id = ?", tender.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	amendment := models.TenderAmendment{
+		TenderID:      tender.ID,
+		Number:        int(count) + 1,
+		TenderVersion: tender.Version,
+		Note:          note,
+		ChangedFields: strings.Join(changed, ","),
+		AuthorID:      authorID,
+	}
+	if err := tx.Create(&amendment).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&models.Bid{}).
+		Where("tender_id = ? AND status = ?", tender.ID, models.CREATEDBid).
+		Update("needs_reconfirmation", true).Error
+}
+
+// Возвращает поля условий тендера, которые отличаются между двумя состояниями
+func changedTenderFields(before, after models.Tender) []string {
+	var changed []string
+	if before.Name != after.Name {
+		changed = append(changed, "name")
+	}
+	if before.Description != after.Description {
+		changed = append(changed, "description")
+	}
+	if before.ServiceType != after.ServiceType {
+		changed = append(changed, "serviceType")
+	}
+	if !sameTime(before.SubmissionDeadline, after.SubmissionDeadline) {
+		changed = append(changed, "submissionDeadline")
+	}
+	if !sameDecimal(before.Budget, after.Budget) {
+		changed = append(changed, "budget")
+	}
+	if before.Currency != after.Currency {
+		changed = append(changed, "currency")
+	}
+	return changed
+}
+
+// Сравнивает необязательные моменты времени
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// Сравнивает необязательные суммы
+func sameDecimal(a, b decimal.NullDecimal) bool {
+	if !a.Valid || !b.Valid {
+		return a.Valid == b.Valid
+	}
+	return a.Decimal.Equal(b.Decimal)
+}
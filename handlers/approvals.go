@@ -0,0 +1,299 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"gorm.io/gorm"
+	"log"
+	"net/http"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+)
+
+// SignOffRequest решение согласующего по тендеру
+type SignOffRequest struct {
+	Decision models.SignOffDecision `json:"decision"`
+	Comment  string                 `json:"comment"`
+}
+
+// SubmitTenderApprovalHandler отправляет черновик тендера на согласование.
+// @Summary Отправка тендера на согласование
+// @Description Начинает новый раунд согласования тендера в статусе CREATED. Число одобрений берется из настройки организации signOffRequired, согласовывать могут только другие ответственные с ролью OWNER, PROCUREMENT_MANAGER или APPROVER. Пока согласование не завершено, тендер нельзя опубликовать.
+// @Tags Approvals
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Success 200 {object} models.TenderApproval "Состояние согласования"
+// @Failure 400 {string} string "Неверный ID, статус тендера, согласование не требуется или недостаточно согласующих"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 409 {string} string "Тендер уже на согласовании или согласован"
+// @Failure 500 {string} string "Ошибка сохранения тендера"
+// @Router /tenders/{tenderId}/approval [post]
+func SubmitTenderApprovalHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findTenderForAction(w, r, validators.ActionEditTender, "У вас нет прав изменять тендер.")
+	if !ok {
+		return
+	}
+	employee, _ := currentEmployee(w, r)
+
+	// Тендер блокируется, чтобы одновременное редактирование не потеряло сброс согласования
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, err := lockTender(tx, tender.ID)
+	if err != nil {
+		writeLockError(w, err)
+		return
+	}
+	if tender.Status != models.CREATED {
+		http.Error(w, "Отправить на согласование можно только тендер в статусе CREATED", http.StatusBadRequest)
+		return
+	}
+	if tender.ApprovalStatus == models.PENDINGApproval || tender.ApprovalStatus == models.APPROVEDApproval {
+		http.Error(w, "Тендер уже на согласовании или согласован", http.StatusConflict)
+		return
+	}
+
+	var organization models.Organization
+	if err := utils.DB.First(&organization, tender.OrganizationID).Error; err != nil {
+		http.Error(w, "Организация тендера не найдена", http.StatusInternalServerError)
+		return
+	}
+	if organization.SignOffRequired == 0 {
+		http.Error(w, "Организация не требует согласования тендеров перед публикацией", http.StatusBadRequest)
+		return
+	}
+
+	// Автор не согласует свой тендер, поэтому других согласующих должно хватать
+	reviewers, err := validators.CountResponsiblesWithPermission(tender.OrganizationID, validators.ActionApproveTender)
+	if err != nil {
+		http.Error(w, "Ошибка подсчета согласующих", http.StatusInternalServerError)
+		return
+	}
+	if validators.CheckPermission(tender.OrganizationID, employee.ID, validators.ActionApproveTender) == nil {
+		reviewers--
+	}
+	if reviewers < int64(organization.SignOffRequired) {
+		http.Error(w, "В организации недостаточно других ответственных для согласования тендера", http.StatusBadRequest)
+		return
+	}
+
+	tender.ApprovalStatus = models.PENDINGApproval
+	tender.ApprovalRound++
+	tender.ApprovalsRequired = organization.SignOffRequired
+	tender.ApprovalSubmittedBy = &employee.ID
+	if err := tx.Save(&tender).Error; err != nil {
+		log.Println("Ошибка отправки тендера на согласование:", err)
+		http.Error(w, "Ошибка сохранения тендера", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка сохранения тендера", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Тендер %d отправлен на согласование, раунд %d", tender.ID, tender.ApprovalRound)
+
+	writeTenderApproval(w, r, tender)
+}
+
+// DecideTenderApprovalHandler одобряет или отклоняет тендер в текущем раунде согласования.
+// @Summary Решение по согласованию тендера
+// @Description Согласующий одобряет (APPROVE) или отклоняет (REJECT) тендер, отклонение требует комментария. Автор отправки не участвует в согласовании, каждый согласующий принимает одно решение за раунд. Отклонение завершает раунд, после исправлений тендер отправляется на согласование заново.
+// @Tags Approvals
+// @Accept  json
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Param signOff body SignOffRequest true "Решение и комментарий"
+// @Success 200 {object} models.TenderApproval "Состояние согласования"
+// @Failure 400 {string} string "Неверный ID, решение или тендер не на согласовании"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на согласование или согласование собственной отправки"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 409 {string} string "Решение в этом раунде уже принято"
+// @Failure 500 {string} string "Ошибка сохранения решения"
+// @Router /tenders/{tenderId}/approval [put]
+func DecideTenderApprovalHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findTenderForAction(w, r, validators.ActionApproveTender, "У вас нет прав согласовывать тендеры организации.")
+	if !ok {
+		return
+	}
+	employee, _ := currentEmployee(w, r)
+
+	var request SignOffRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные решения", http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckSignOff(request.Decision, request.Comment); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Тендер блокируется до конца транзакции: одновременное редактирование сбрасывает согласование,
+	// и решение не должно затереть этот сброс, а параллельные одобрения — дважды завершить раунд
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, err := lockTender(tx, tender.ID)
+	if err != nil {
+		writeLockError(w, err)
+		return
+	}
+	if tender.Status != models.CREATED || tender.ApprovalStatus != models.PENDINGApproval {
+		http.Error(w, "Тендер не находится на согласовании", http.StatusBadRequest)
+		return
+	}
+	if tender.ApprovalSubmittedBy != nil && *tender.ApprovalSubmittedBy == employee.ID {
+		http.Error(w, "Нельзя согласовывать тендер, отправленный на согласование вами", http.StatusForbidden)
+		return
+	}
+
+	var existing models.TenderSignOff
+	err = tx.Where("tender_id = ? AND round = ? AND reviewer_id = ?", tender.ID, tender.ApprovalRound, employee.ID).
+		First(&existing).Error
+	if err == nil {
+		http.Error(w, "Вы уже приняли решение в этом раунде согласования", http.StatusConflict)
+		return
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		http.Error(w, "Ошибка проверки решений", http.StatusInternalServerError)
+		return
+	}
+
+	signOff := models.TenderSignOff{
+		TenderID:   tender.ID,
+		Round:      tender.ApprovalRound,
+		ReviewerID: employee.ID,
+		Decision:   request.Decision,
+		Comment:    request.Comment,
+	}
+	if err := tx.Create(&signOff).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "Вы уже приняли решение в этом раунде согласования", http.StatusConflict)
+			return
+		}
+		log.Println("Ошибка сохранения решения по согласованию:", err)
+		http.Error(w, "Ошибка сохранения решения", http.StatusInternalServerError)
+		return
+	}
+
+	if request.Decision == models.REJECTSignOff {
+		tender.ApprovalStatus = models.REJECTEDApproval
+	} else {
+		approvals, err := countApprovals(tx, tender)
+		if err != nil {
+			http.Error(w, "Ошибка подсчета одобрений", http.StatusInternalServerError)
+			return
+		}
+		if approvals >= int64(tender.ApprovalsRequired) {
+			tender.ApprovalStatus = models.APPROVEDApproval
+		}
+	}
+	if tender.ApprovalStatus != models.PENDINGApproval {
+		if err := tx.Model(&tender).Update("approval_status", tender.ApprovalStatus).Error; err != nil {
+			http.Error(w, "Ошибка сохранения решения", http.StatusInternalServerError)
+			return
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка сохранения решения", http.StatusInternalServerError)
+		return
+	}
+	if tender.ApprovalStatus != models.PENDINGApproval {
+		log.Printf("Согласование тендера %d завершено: %s", tender.ID, tender.ApprovalStatus)
+	}
+
+	writeTenderApproval(w, r, tender)
+}
+
+// GetTenderApprovalHandler возвращает состояние согласования тендера.
+// @Summary Состояние согласования тендера
+// @Description Возвращает статус текущего раунда согласования, число нужных и полученных одобрений и все решения с комментариями по всем раундам.
+// @Tags Approvals
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Success 200 {object} models.TenderApproval "Состояние согласования"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка загрузки решений"
+// @Router /tenders/{tenderId}/approval [get]
+func GetTenderApprovalHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findTenderForAction(w, r, validators.ActionViewTender, "У вас нет прав просматривать тендер.")
+	if !ok {
+		return
+	}
+	writeTenderApproval(w, r, tender)
+}
+
+// Отвечает клиенту состоянием согласования тендера вместе с историей решений
+func writeTenderApproval(w http.ResponseWriter, r *http.Request, tender models.Tender) {
+	approval := models.TenderApproval{
+		TenderID:    tender.ID,
+		Status:      tender.ApprovalStatus,
+		Round:       tender.ApprovalRound,
+		Required:    tender.ApprovalsRequired,
+		SubmittedBy: tender.ApprovalSubmittedBy,
+	}
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Order("round, id").Find(&approval.SignOffs).Error; err != nil {
+		http.Error(w, "Ошибка загрузки решений", http.StatusInternalServerError)
+		return
+	}
+	for _, signOff := range approval.SignOffs {
+		if signOff.Round == tender.ApprovalRound && signOff.Decision == models.APPROVESignOff {
+			approval.Approvals++
+		}
+	}
+	utils.JSONFormat(w, r, approval)
+}
+
+// Число одобрений тендера в текущем раунде согласования
+func countApprovals(tx *gorm.DB, tender models.Tender) (int64, error) {
+	var count int64
+	err := tx.Model(&models.TenderSignOff{}).
+		Where("tender_id = ? AND round = ? AND decision = ?", tender.ID, tender.ApprovalRound, models.APPROVESignOff).
+		Count(&count).Error
+	return count, err
+}
+
+// Проверяет, что тендер прошел обязательное согласование, если организация его требует
+func checkTenderSignedOff(tender models.Tender) error {
+	var organization models.Organization
+	if err := utils.DB.Select("sign_off_required").First(&organization, tender.OrganizationID).Error; err != nil {
+		return errors.New("Организация тендера не найдена")
+	}
+	if organization.SignOffRequired > 0 && tender.ApprovalStatus != models.APPROVEDApproval {
+		return errors.New("Тендер нельзя опубликовать до завершения согласования")
+	}
+	return nil
+}
+
+// Сбрасывает согласование черновика после изменения условий, тендер нужно согласовать заново
+func resetTenderApproval(tender *models.Tender) {
+	if tender.Status == models.CREATED && tender.ApprovalStatus != "" {
+		tender.ApprovalStatus = ""
+	}
+}
+
+// Блокирует тендер перед изменением его лотов, критериев, приглашений или файлов и сбрасывает согласование
+// черновика, как при редактировании самого тендера. При ошибке сам отвечает клиенту
+func lockTenderForChange(w http.ResponseWriter, tx *gorm.DB, tenderID uint) (models.Tender, bool) {
+	tender, err := lockTender(tx, tenderID)
+	if err != nil {
+		writeLockError(w, err)
+		return models.Tender{}, false
+	}
+	approvalStatus := tender.ApprovalStatus
+	resetTenderApproval(&tender)
+	if tender.ApprovalStatus != approvalStatus {
+		if err := tx.Model(&tender).Update("approval_status", tender.ApprovalStatus).Error; err != nil {
+			http.Error(w, "Ошибка сброса согласования тендера", http.StatusInternalServerError)
+			return models.Tender{}, false
+		}
+	}
+	return tender, true
+}
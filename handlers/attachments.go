@@ -0,0 +1,511 @@
+package handlers
+
+import (
+	"bufio"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/gorilla/mux"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"testAvito/config"
+	"testAvito/middleware"
+	"testAvito/models"
+	"testAvito/storage"
+	"testAvito/utils"
+	"testAvito/validators"
+)
+
+// UploadTenderAttachmentHandler прикладывает файл к тендеру.
+// @Summary Загрузка файла к тендеру
+// @Description Принимает multipart/form-data с файлом в поле file. Файл проверяется по размеру и типу, для него считается SHA-256. Загружать файлы могут ответственные, которым разрешено изменять тендер, пока тендер не завершен.
+// @Tags Attachments
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Param file formData file true "Файл"
+// @Security BearerAuth
+// @Success 200 {object} models.Attachment "Сохраненное вложение"
+// @Failure 400 {string} string "Неверный файл, ID или статус тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 413 {string} string "Файл слишком большой"
+// @Failure 500 {string} string "Ошибка сохранения файла"
+// @Router /tenders/{tenderId}/attachments [post]
+func UploadTenderAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	tender, ok := findTenderForAction(w, r, validators.ActionEditTender, "У вас нет прав изменять тендер.")
+	if !ok {
+		return
+	}
+	if tenderFinished(tender) {
+		http.Error(w, "Тендер был закрыт или отменен, изменения невозможны.", http.StatusBadRequest)
+		return
+	}
+
+	// Содержимое сохраняется до блокировки тендера, чтобы медленная загрузка не задерживала работу с ним
+	attachment := models.Attachment{TenderID: &tender.ID, UploaderID: employee.ID}
+	if !receiveAttachment(w, r, &attachment) {
+		return
+	}
+	if !createTenderAttachment(w, &attachment) {
+		storage.Files.Delete(attachment.StorageKey)
+		return
+	}
+	utils.JSONFormat(w, r, attachment)
+}
+
+// GetTenderAttachmentsHandler возвращает файлы тендера.
+// @Summary Список файлов тендера
+// @Description Возвращает метаданные файлов тендера всем, кто видит тендер (в том числе без авторизации для опубликованных публичных тендеров).
+// @Tags Attachments
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Success 200 {array} models.Attachment "Список файлов"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка загрузки файлов"
+// @Router /tenders/{tenderId}/attachments [get]
+func GetTenderAttachmentsHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := strconv.Atoi(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "Неверный тендер ID", http.StatusBadRequest)
+		return
+	}
+	var tender models.Tender
+	if err := utils.DB.First(&tender, tenderID).Error; err != nil {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return
+	}
+	// Смотреть файлы можно и без авторизации, если тендер виден всем
+	var viewer *models.Employee
+	if employee, ok := middleware.EmployeeFromContext(r.Context()); ok {
+		viewer = &employee
+	}
+	if !canViewTender(tender, viewer) {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return
+	}
+
+	var attachments []models.Attachment
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Order("id").Find(&attachments).Error; err != nil {
+		http.Error(w, "Ошибка загрузки файлов", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, attachments)
+}
+
+// UploadBidAttachmentHandler прикладывает файл к предложению.
+// @Summary Загрузка файла к предложению
+// @Description Принимает multipart/form-data с файлом в поле file. Загружать файлы может автор предложения (для организации — ответственные с правом подавать предложения), пока предложение не отменено и не истек срок подачи предложений.
+// @Tags Attachments
+// @Accept  multipart/form-data
+// @Produce  json
+// @Param bidId path int true "ID предложения"
+// @Param file formData file true "Файл"
+// @Security BearerAuth
+// @Success 200 {object} models.Attachment "Сохраненное вложение"
+// @Failure 400 {string} string "Неверный файл, ID или статус предложения"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение предложения"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 413 {string} string "Файл слишком большой"
+// @Failure 500 {string} string "Ошибка сохранения файла"
+// @Router /bids/{bidId}/attachments [post]
+func UploadBidAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	bid, tender, ok := findBidWithTender(w, r)
+	if !ok {
+		return
+	}
+	if !canManageBid(bid, employee.ID) {
+		http.Error(w, "Только автор предложения может прикладывать к нему файлы", http.StatusForbidden)
+		return
+	}
+	if bid.Status == models.CANCELED || !submissionOpen(tender) {
+		http.Error(w, "Предложение отменено или прием предложений завершен, изменения невозможны.", http.StatusBadRequest)
+		return
+	}
+
+	attachment := models.Attachment{BidID: &bid.ID, UploaderID: employee.ID}
+	if !storeAttachment(w, r, &attachment) {
+		return
+	}
+	utils.JSONFormat(w, r, attachment)
+}
+
+// GetBidAttachmentsHandler возвращает файлы предложения.
+// @Summary Список файлов предложения
+// @Description Возвращает метаданные файлов предложения его автору и ответственным за тендер. Файлы предложений закрытого (sealed) тендера ответственные видят только после вскрытия.
+// @Tags Attachments
+// @Produce  json
+// @Param bidId path int true "ID предложения"
+// @Security BearerAuth
+// @Success 200 {array} models.Attachment "Список файлов"
+// @Failure 400 {string} string "Неверный ID предложения"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр предложения"
+// @Failure 404 {string} string "Предложение не найдено"
+// @Failure 500 {string} string "Ошибка загрузки файлов"
+// @Router /bids/{bidId}/attachments [get]
+func GetBidAttachmentsHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	bid, tender, ok := findBidWithTender(w, r)
+	if !ok {
+		return
+	}
+	if !canViewBidDetails(bid, tender, employee.ID) {
+		http.Error(w, "У вас нет прав просматривать файлы предложения", http.StatusForbidden)
+		return
+	}
+
+	var attachments []models.Attachment
+	if err := utils.DB.Where("bid_id = ?", bid.ID).Order("id").Find(&attachments).Error; err != nil {
+		http.Error(w, "Ошибка загрузки файлов", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, attachments)
+}
+
+// DownloadAttachmentHandler отдает содержимое файла.
+// @Summary Скачивание файла
+// @Description Отдает файл тендера или предложения с теми же правами, что и просмотр самого тендера или предложения. В заголовке ETag передается SHA-256 содержимого.
+// @Tags Attachments
+// @Produce  octet-stream
+// @Param attachmentId path int true "ID файла"
+// @Security BearerAuth
+// @Success 200 {file} file "Содержимое файла"
+// @Failure 400 {string} string "Неверный ID файла"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр предложения"
+// @Failure 404 {string} string "Файл не найден"
+// @Failure 500 {string} string "Ошибка чтения файла"
+// @Router /attachments/{attachmentId} [get]
+func DownloadAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	attachment, ok := findAttachment(w, r)
+	if !ok {
+		return
+	}
+
+	if attachment.TenderID != nil {
+		var viewer *models.Employee
+		if employee, ok := middleware.EmployeeFromContext(r.Context()); ok {
+			viewer = &employee
+		}
+		var tender models.Tender
+		if err := utils.DB.First(&tender, *attachment.TenderID).Error; err != nil || !canViewTender(tender, viewer) {
+			http.Error(w, "Файл не найден", http.StatusNotFound)
+			return
+		}
+	} else {
+		employee, ok := currentEmployee(w, r)
+		if !ok {
+			return
+		}
+		bid, tender, err := attachmentBid(attachment)
+		if err != nil {
+			http.Error(w, "Файл не найден", http.StatusNotFound)
+			return
+		}
+		if !canViewBidDetails(bid, tender, employee.ID) {
+			http.Error(w, "У вас нет прав просматривать файлы предложения", http.StatusForbidden)
+			return
+		}
+	}
+
+	content, err := storage.Files.Open(attachment.StorageKey)
+	if err != nil {
+		log.Println("Ошибка чтения файла:", err)
+		http.Error(w, "Ошибка чтения файла", http.StatusInternalServerError)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("ETag", `"`+attachment.SHA256+`"`)
+	if _, err := io.Copy(w, content); err != nil {
+		log.Println("Ошибка отправки файла:", err)
+	}
+}
+
+// DeleteAttachmentHandler удаляет файл.
+// @Summary Удаление файла
+// @Description Удаляет файл тендера (ответственные, которым разрешено изменять тендер) или предложения (автор предложения), пока тендер не завершен.
+// @Tags Attachments
+// @Produce  json
+// @Param attachmentId path int true "ID файла"
+// @Security BearerAuth
+// @Success 200 {object} models.Attachment "Удаленный файл"
+// @Failure 400 {string} string "Неверный ID файла или тендер завершен"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение тендера или предложения"
+// @Failure 404 {string} string "Файл не найден"
+// @Failure 500 {string} string "Ошибка удаления файла"
+// @Router /attachments/{attachmentId} [delete]
+func DeleteAttachmentHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	attachment, ok := findAttachment(w, r)
+	if !ok {
+		return
+	}
+
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	var tender models.Tender
+	if attachment.TenderID != nil {
+		if err := utils.DB.First(&tender, *attachment.TenderID).Error; err != nil {
+			http.Error(w, "Файл не найден", http.StatusNotFound)
+			return
+		}
+		if !checkPermission(w, tender.OrganizationID, employee.ID, validators.ActionEditTender, "У вас нет прав изменять тендер.") {
+			return
+		}
+		// Файлы тендера входят в условия черновика, их удаление сбрасывает согласование
+		if tender, ok = lockTenderForChange(w, tx, tender.ID); !ok {
+			return
+		}
+	} else {
+		bid, bidTender, err := attachmentBid(attachment)
+		if err != nil {
+			http.Error(w, "Файл не найден", http.StatusNotFound)
+			return
+		}
+		if !canManageBid(bid, employee.ID) {
+			http.Error(w, "Только автор предложения может удалять его файлы", http.StatusForbidden)
+			return
+		}
+		if !submissionOpen(bidTender) {
+			http.Error(w, "Прием предложений по тендеру завершен, изменения невозможны.", http.StatusBadRequest)
+			return
+		}
+		tender = bidTender
+	}
+	if tenderFinished(tender) {
+		http.Error(w, "Тендер был закрыт или отменен, изменения невозможны.", http.StatusBadRequest)
+		return
+	}
+
+	if err := tx.Delete(&attachment).Error; err != nil {
+		http.Error(w, "Ошибка удаления файла", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка удаления файла", http.StatusInternalServerError)
+		return
+	}
+	// Запись уже удалена, поэтому оставшийся в хранилище файл только занимает место
+	if err := storage.Files.Delete(attachment.StorageKey); err != nil {
+		log.Println("Ошибка удаления файла из хранилища:", err)
+	}
+	utils.JSONFormat(w, r, attachment)
+}
+
+// Принимает файл из поля file, проверяет его и сохраняет содержимое в хранилище, а метаданные — в базу
+func storeAttachment(w http.ResponseWriter, r *http.Request, attachment *models.Attachment) bool {
+	if !receiveAttachment(w, r, attachment) {
+		return false
+	}
+	if err := utils.DB.Create(attachment).Error; err != nil {
+		storage.Files.Delete(attachment.StorageKey)
+		log.Println("Ошибка сохранения вложения:", err)
+		http.Error(w, "Ошибка сохранения файла", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// Сохраняет метаданные файла тендера под блокировкой тендера и сбрасывает согласование черновика
+func createTenderAttachment(w http.ResponseWriter, attachment *models.Attachment) bool {
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, ok := lockTenderForChange(w, tx, *attachment.TenderID)
+	if !ok {
+		return false
+	}
+	// Тендер могли закрыть, пока загружался файл
+	if tenderFinished(tender) {
+		http.Error(w, "Тендер был закрыт или отменен, изменения невозможны.", http.StatusBadRequest)
+		return false
+	}
+	if err := tx.Create(attachment).Error; err != nil {
+		log.Println("Ошибка сохранения вложения:", err)
+		http.Error(w, "Ошибка сохранения файла", http.StatusInternalServerError)
+		return false
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка сохранения файла", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// Принимает файл из поля file, проверяет его и сохраняет содержимое в хранилище, заполняя метаданные вложения
+func receiveAttachment(w http.ResponseWriter, r *http.Request, attachment *models.Attachment) bool {
+	maxSize := config.AttachmentMaxSize()
+	// Запас на заголовки multipart поверх самого файла
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize+1<<20)
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		http.Error(w, "Ожидается multipart/form-data с файлом в поле file", http.StatusBadRequest)
+		return false
+	}
+	var part io.Reader
+	for {
+		next, err := reader.NextPart()
+		if err != nil {
+			if tooLarge(err) {
+				http.Error(w, "Файл слишком большой", http.StatusRequestEntityTooLarge)
+				return false
+			}
+			http.Error(w, "Ожидается multipart/form-data с файлом в поле file", http.StatusBadRequest)
+			return false
+		}
+		if next.FormName() == "file" {
+			attachment.FileName = filepath.Base(next.FileName())
+			part = next
+			break
+		}
+	}
+
+	// Тип определяется по первым 512 байтам, как в http.DetectContentType
+	buffered := bufio.NewReaderSize(part, 512)
+	head, _ := buffered.Peek(512)
+	contentType, err := validators.CheckAttachmentType(attachment.FileName, http.DetectContentType(head))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return false
+	}
+	attachment.ContentType = contentType
+
+	key, err := newStorageKey()
+	if err != nil {
+		http.Error(w, "Ошибка сохранения файла", http.StatusInternalServerError)
+		return false
+	}
+	hash := sha256.New()
+	size, err := storage.Files.Save(key, io.TeeReader(io.LimitReader(buffered, maxSize+1), hash))
+	if err != nil {
+		storage.Files.Delete(key)
+		if tooLarge(err) {
+			http.Error(w, "Файл слишком большой", http.StatusRequestEntityTooLarge)
+			return false
+		}
+		log.Println("Ошибка сохранения файла:", err)
+		http.Error(w, "Ошибка сохранения файла", http.StatusInternalServerError)
+		return false
+	}
+	if err := validators.CheckAttachmentSize(size, maxSize); err != nil {
+		storage.Files.Delete(key)
+		status := http.StatusBadRequest
+		if size > maxSize {
+			status = http.StatusRequestEntityTooLarge
+		}
+		http.Error(w, err.Error(), status)
+		return false
+	}
+
+	attachment.Size = size
+	attachment.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	attachment.StorageKey = key
+	return true
+}
+
+func tooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+// Случайный ключ файла в хранилище, имя от пользователя в путь не попадает
+func newStorageKey() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+func findAttachment(w http.ResponseWriter, r *http.Request) (models.Attachment, bool) {
+	attachmentID, err := strconv.Atoi(mux.Vars(r)["attachmentId"])
+	if err != nil {
+		http.Error(w, "Неверный ID файла", http.StatusBadRequest)
+		return models.Attachment{}, false
+	}
+	var attachment models.Attachment
+	if err := utils.DB.First(&attachment, attachmentID).Error; err != nil {
+		http.Error(w, "Файл не найден", http.StatusNotFound)
+		return models.Attachment{}, false
+	}
+	return attachment, true
+}
+
+func findBidWithTender(w http.ResponseWriter, r *http.Request) (models.Bid, models.Tender, bool) {
+	bidID, err := strconv.Atoi(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "Неверный ID предложения", http.StatusBadRequest)
+		return models.Bid{}, models.Tender{}, false
+	}
+	var bid models.Bid
+	if err := utils.DB.First(&bid, bidID).Error; err != nil {
+		http.Error(w, "Предложение не найдено", http.StatusNotFound)
+		return models.Bid{}, models.Tender{}, false
+	}
+	var tender models.Tender
+	if err := utils.DB.First(&tender, bid.TenderID).Error; err != nil {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return models.Bid{}, models.Tender{}, false
+	}
+	return bid, tender, true
+}
+
+func attachmentBid(attachment models.Attachment) (models.Bid, models.Tender, error) {
+	var bid models.Bid
+	if err := utils.DB.First(&bid, *attachment.BidID).Error; err != nil {
+		return models.Bid{}, models.Tender{}, err
+	}
+	var tender models.Tender
+	if err := utils.DB.First(&tender, bid.TenderID).Error; err != nil {
+		return models.Bid{}, models.Tender{}, err
+	}
+	return bid, tender, nil
+}
+
+// Автор предложения: сам сотрудник или ответственный организации с правом подавать предложения
+func canManageBid(bid models.Bid, employeeID uint) bool {
+	switch bid.AuthorType {
+	case models.USER:
+		return bid.AuthorID == employeeID
+	case models.ORGANIZATION:
+		return validators.CheckPermission(bid.AuthorID, employeeID, validators.ActionManageBid) == nil
+	default:
+		return false
+	}
+}
+
+// Файлы и историю версий предложения видят его автор и ответственные за тендер, для закрытого тендера — после вскрытия
+func canViewBidDetails(bid models.Bid, tender models.Tender, employeeID uint) bool {
+	if ownsBid(bid, employeeID) {
+		return true
+	}
+	return bidsUnsealed(tender) && validators.CheckPermission(tender.OrganizationID, employeeID, validators.ActionViewBids) == nil
+}
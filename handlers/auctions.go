@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"log"
+	"net/http"
+	"strconv"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// LowerPriceRequest новая цена предложения в аукционе
+type LowerPriceRequest struct {
+	Amount decimal.Decimal `json:"amount" swaggertype:"string"`
+}
+
+// LowerBidPriceHandler снижает цену предложения в аукционе на понижение.
+// @Summary Снижение цены в аукционе
+// @Description Снижает цену существующего предложения в текущем раунде аукциона. Новая цена должна быть ниже текущей лучшей цены как минимум на шаг аукциона. Предложение, поступившее в окно продления перед концом раунда, продлевает раунд.
+// @Tags Auctions
+// @Accept  json
+// @Produce  json
+// @Param bidId path int true "ID предложения"
+// @Security BearerAuth
+// @Param price body LowerPriceRequest true "Новая цена предложения"
+// @Success 200 {object} models.Bid "Предложение с новой ценой"
+// @Failure 400 {string} string "Неверная цена, ID предложения или аукцион не идет"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав для изменения предложения"
+// @Failure 404 {string} string "Предложение или тендер не найдены"
+// @Failure 500 {string} string "Ошибка сохранения предложения"
+// @Router /bids/{bidId}/lower_price [put]
+func LowerBidPriceHandler(w http.ResponseWriter, r *http.Request) {
+	bidId, err := strconv.Atoi(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "Неверный ID предложения", http.StatusBadRequest)
+		return
+	}
+
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	var request LowerPriceRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверный формат amount, ожидается число", http.StatusBadRequest)
+		return
+	}
+
+	// Тендер и предложение блокируются до конца транзакции, чтобы параллельные ставки сравнивались с актуальной ценой
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	bid, tender, err := lockBidWithTender(tx, uint(bidId))
+	if err != nil {
+		writeLockError(w, err)
+		return
+	}
+
+	switch bid.AuthorType {
+	case models.USER:
+		if bid.AuthorID != employee.ID {
+			http.Error(w, "Только автор предложения может снижать его цену", http.StatusForbidden)
+			return
+		}
+
+	case models.ORGANIZATION:
+		if !checkPermission(w, bid.AuthorID, employee.ID, validators.ActionManageBid, "Только члены организации могут снижать цену предложения") {
+			return
+		}
+	default:
+		http.Error(w, "Неверный тип автора предложения", http.StatusBadRequest)
+		return
+	}
+
+	if bid.Status == models.CANCELED || bid.Status == models.PUBLISHEDBid {
+		http.Error(w, "Предложение уже не участвует в аукционе", http.StatusBadRequest)
+		return
+	}
+
+	if tender.Type != models.AUCTION {
+		http.Error(w, "Тендер не является аукционом", http.StatusBadRequest)
+		return
+	}
+	if !auctionRunning(tender) {
+		http.Error(w, "Аукцион не идет, снижение цены невозможно", http.StatusBadRequest)
+		return
+	}
+
+	if bid.Amount.Valid && !request.Amount.LessThan(bid.Amount.Decimal) {
+		http.Error(w, "Новая цена должна быть ниже текущей цены предложения", http.StatusBadRequest)
+		return
+	}
+	best, err := bestAuctionPrice(tx, tender.ID, 0)
+	if err != nil {
+		http.Error(w, "Ошибка определения лучшей цены аукциона", http.StatusInternalServerError)
+		return
+	}
+	if err := validators.CheckAuctionPrice(request.Amount, best, tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bid.Amount = decimal.NewNullDecimal(request.Amount)
+	bid.NeedsReconfirmation = false
+	bid.Version++
+	if err := tx.Save(&bid).Error; err != nil {
+		http.Error(w, "Ошибка сохранения предложения", http.StatusInternalServerError)
+		return
+	}
+	if err := saveBidsVersion(tx, bid, &employee.ID); err != nil {
+		http.Error(w, "Ошибка сохранения версии предложения", http.StatusInternalServerError)
+		return
+	}
+
+	if err := registerAuctionBid(tx, &tender); err != nil {
+		http.Error(w, "Ошибка обновления раунда аукциона", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка сохранения предложения", http.StatusInternalServerError)
+		return
+	}
+
+	utils.JSONFormat(w, r, bid)
+}
+
+// GetAuctionRankingHandler возвращает текущий рейтинг предложений аукциона.
+// @Summary Рейтинг аукциона
+// @Description Возвращает предложения аукциона по возрастанию цены, текущий раунд и время его окончания. Ответственные за тендер видят ID всех предложений, участники аукциона — только свои.
+// @Tags Auctions
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Success 200 {object} models.AuctionRanking "Рейтинг аукциона"
+// @Failure 400 {string} string "Неверный ID тендера или тендер не является аукционом"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр рейтинга"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка загрузки предложений"
+// @Router /bids/{tenderId}/ranking [get]
+func GetAuctionRankingHandler(w http.ResponseWriter, r *http.Request) {
+	tenderId, err := strconv.Atoi(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "Неверный тендер ID", http.StatusBadRequest)
+		return
+	}
+
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	var tender models.Tender
+	if err := utils.DB.First(&tender, tenderId).Error; err != nil {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return
+	}
+	if tender.Type != models.AUCTION {
+		http.Error(w, "Тендер не является аукционом", http.StatusBadRequest)
+		return
+	}
+
+	var bids []models.Bid
+	if err := utils.DB.Where("tender_id = ? AND status <> ? AND amount IS NOT NULL", tender.ID, models.CANCELED).
+		Order("amount, updated_at, id").Find(&bids).Error; err != nil {
+		http.Error(w, "Ошибка загрузки предложений", http.StatusInternalServerError)
+		return
+	}
+
+	// Ответственные за тендер видят весь рейтинг, участники — только место своих предложений
+	organizer := validators.CheckPermission(tender.OrganizationID, employee.ID, validators.ActionViewBids) == nil
+	participant := false
+	ranking := models.AuctionRanking{
+		TenderID:    tender.ID,
+		Status:      tender.Status,
+		Round:       tender.AuctionRound,
+		RoundEndsAt: tender.AuctionRoundEndsAt,
+		Step:        tender.AuctionStep,
+		Currency:    tender.Currency,
+		Positions:   make([]models.AuctionPosition, 0, len(bids)),
+	}
+	for i, bid := range bids {
+		own := ownsBid(bid, employee.ID)
+		participant = participant || own
+		position := models.AuctionPosition{
+			Place:     i + 1,
+			Own:       own,
+			Amount:    bid.Amount.Decimal,
+			UpdatedAt: bid.UpdatedAt,
+		}
+		if organizer || own {
+			position.BidID = bid.ID
+		}
+		ranking.Positions = append(ranking.Positions, position)
+	}
+	if !organizer && !participant {
+		http.Error(w, "Рейтинг доступен только ответственным за тендер и участникам аукциона", http.StatusForbidden)
+		return
+	}
+
+	utils.JSONFormat(w, r, ranking)
+}
+
+// Аукцион идет, пока тендер опубликован и текущий раунд не истек
+func auctionRunning(tender models.Tender) bool {
+	return tender.Status == models.PUBLISHED && tender.AuctionRoundEndsAt != nil && time.Now().Before(*tender.AuctionRoundEndsAt)
+}
+
+// Лучшая (минимальная) цена среди действующих предложений аукциона, exceptBidID исключает предложение из расчета
+func bestAuctionPrice(tx *gorm.DB, tenderID uint, exceptBidID uint) (decimal.NullDecimal, error) {
+	var best decimal.NullDecimal
+	err := tx.Model(&models.Bid{}).
+		Where("tender_id = ? AND id <> ? AND status <> ? AND amount IS NOT NULL", tenderID, exceptBidID, models.CANCELED).
+		Select("MIN(amount)").Row().Scan(&best)
+	return best, err
+}
+
+// Учитывает снижение цены в текущем раунде и продлевает раунд, если предложение пришло в окно продления
+func registerAuctionBid(tx *gorm.DB, tender *models.Tender) error {
+	tender.AuctionRoundBids++
+	if tender.AuctionExtensionSeconds > 0 && tender.AuctionRoundEndsAt != nil {
+		extension := time.Duration(tender.AuctionExtensionSeconds) * time.Second
+		if time.Until(*tender.AuctionRoundEndsAt) < extension {
+			endsAt := time.Now().Add(extension)
+			tender.AuctionRoundEndsAt = &endsAt
+		}
+	}
+	return tx.Save(tender).Error
+}
+
+// Завершает аукцион: предложение с лучшей ценой побеждает, тендер закрывается тем же путем, что и по кворуму
+func finishAuction(tx *gorm.DB, tender *models.Tender) error {
+	tender.AuctionRoundEndsAt = nil
+
+	// Неподтвержденные после изменения условий предложения победить не могут
+	var winner models.Bid
+	err := tx.Where("tender_id = ? AND status <> ? AND amount IS NOT NULL AND NOT needs_reconfirmation", tender.ID, models.CANCELED).
+		Order("amount, updated_at, id").Limit(1).Find(&winner).Error
+	if err != nil {
+		return err
+	}
+	if winner.ID == 0 {
+		// Никто не предложил цену, тендер закрывается без победителя
+		if err := closeTender(tx, tender); err != nil {
+			return err
+		}
+		if err := tx.Save(tender).Error; err != nil {
+			return err
+		}
+		if err := saveTenderVersion(tx, *tender, nil); err != nil {
+			return err
+		}
+		log.Printf("Аукцион по тендеру %d завершен без предложений", tender.ID)
+		return nil
+	}
+
+	if err := awardBid(tx, tender, &winner); err != nil {
+		return err
+	}
+	log.Printf("Аукцион по тендеру %d завершен, победило предложение %d с ценой %s", tender.ID, winner.ID, winner.Amount.Decimal.StringFixed(2))
+	return nil
+}
+
+// Проверяет, что сотрудник автор предложения или член организации-автора
+func ownsBid(bid models.Bid, employeeID uint) bool {
+	switch bid.AuthorType {
+	case models.USER:
+		return bid.AuthorID == employeeID
+	case models.ORGANIZATION:
+		return validators.CheckPermission(bid.AuthorID, employeeID, validators.ActionViewBids) == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"testAvito/middleware"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// LoginRequest данные для входа сотрудника
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoginResponse выданный токен доступа
+type LoginResponse struct {
+	Token     string    `json:"token"`
+	TokenType string    `json:"token_type"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LoginHandler выдает токен доступа по имени пользователя и паролю.
+// @Summary Вход сотрудника
+// @Description Проверяет имя пользователя и пароль и возвращает bearer-токен, который нужно передавать в заголовке Authorization.
+// @Tags Auth
+// @Accept  json
+// @Produce  json
+// @Param credentials body LoginRequest true "Имя пользователя и пароль"
+// @Success 200 {object} LoginResponse "Выданный токен"
+// @Failure 400 {string} string "Неверные данные"
+// @Failure 401 {string} string "Неверное имя пользователя или пароль"
+// @Failure 500 {string} string "Ошибка выпуска токена"
+// @Router /auth/login [post]
+func LoginHandler(w http.ResponseWriter, r *http.Request) {
+	var credentials LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		http.Error(w, "Неверные данные", http.StatusBadRequest)
+		return
+	}
+	if credentials.Username == "" || credentials.Password == "" {
+		http.Error(w, "Необходимо ввести имя пользователя и пароль", http.StatusBadRequest)
+		return
+	}
+
+	var employee models.Employee
+	if err := utils.DB.Where("username = ?", credentials.Username).First(&employee).Error; err != nil {
+		http.Error(w, "Неверное имя пользователя или пароль", http.StatusUnauthorized)
+		return
+	}
+	// Сотрудник без пароля войти не может
+	if employee.PasswordHash == "" {
+		http.Error(w, "Неверное имя пользователя или пароль", http.StatusUnauthorized)
+		return
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(employee.PasswordHash), []byte(credentials.Password)); err != nil {
+		http.Error(w, "Неверное имя пользователя или пароль", http.StatusUnauthorized)
+		return
+	}
+
+	token, expiresAt, err := utils.GenerateToken(employee.ID, employee.Username)
+	if err != nil {
+		log.Println("Ошибка выпуска токена:", err)
+		http.Error(w, "Ошибка выпуска токена", http.StatusInternalServerError)
+		return
+	}
+
+	utils.JSONFormat(w, r, LoginResponse{
+		Token:     token,
+		TokenType: "Bearer",
+		ExpiresAt: expiresAt,
+	})
+}
+
+// Достает авторизованного сотрудника из контекста, при его отсутствии отвечает 401
+func currentEmployee(w http.ResponseWriter, r *http.Request) (models.Employee, bool) {
+	employee, ok := middleware.EmployeeFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Необходима авторизация", http.StatusUnauthorized)
+		return models.Employee{}, false
+	}
+	return employee, true
+}
+
+// Проверяет право сотрудника на действие в организации, при отказе сам отвечает клиенту
+func checkPermission(w http.ResponseWriter, orgID uint, employeeID uint, action validators.Action, message string) bool {
+	err := validators.CheckPermission(orgID, employeeID, action)
+	if err == nil {
+		return true
+	}
+	if errors.Is(err, validators.ErrPermissionCheckDB) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return false
+	}
+	http.Error(w, message, http.StatusForbidden)
+	return false
+}
+
+// Проверяет If-Match по текущей версии, при устаревшей версии сам отвечает клиенту 412 с актуальным ETag
+func checkIfMatch(w http.ResponseWriter, r *http.Request, version int) bool {
+	if utils.IfMatchVersion(r, version) {
+		return true
+	}
+	w.Header().Set("ETag", utils.VersionETag(version))
+	http.Error(w, "Данные изменены другим пользователем, загрузите актуальную версию", http.StatusPreconditionFailed)
+	return false
+}
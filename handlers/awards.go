@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"strconv"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+
+	"github.com/shopspring/decimal"
+)
+
+// AwardRequest доля и объем поставки победителя, null снимает значение
+type AwardRequest struct {
+	Share    decimal.NullDecimal `json:"share" swaggertype:"string"`
+	Quantity decimal.NullDecimal `json:"quantity" swaggertype:"string"`
+}
+
+// SetBidAwardHandler задает долю и объем поставки победившему предложению.
+// @Summary Доля победителя
+// @Description Задает победившему (PUBLISHED) предложению долю share в процентах и/или объем поставки quantity. Сумма долей победителей тендера (или лота) не может превышать 100%. Доступно ответственным с правом закрывать тендер.
+// @Tags Bids
+// @Accept  json
+// @Produce  json
+// @Param bidId path int true "ID предложения"
+// @Security BearerAuth
+// @Param award body AwardRequest true "Доля и объем поставки"
+// @Param If-Match header string false "ETag версии, которую видел клиент"
+// @Success 200 {object} models.Bid "Обновленное предложение"
+// @Header 200 {string} ETag "Версия объекта после изменения"
+// @Failure 400 {string} string "Неверные данные, ID или предложение не победило"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав присуждать тендер"
+// @Failure 404 {string} string "Предложение или тендер не найдены"
+// @Failure 412 {string} string "Версия устарела, объект изменен другим пользователем"
+// @Failure 500 {string} string "Ошибка сохранения доли"
+// @Router /bids/{bidId}/award [put]
+func SetBidAwardHandler(w http.ResponseWriter, r *http.Request) {
+	bidID, err := strconv.Atoi(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "Неверный ID предложения", http.StatusBadRequest)
+		return
+	}
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	var request AwardRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные", http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckAmount(request.Quantity); err != nil {
+		http.Error(w, "Неверный объем quantity: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Тендер блокируется до конца транзакции, чтобы параллельные присуждения не превысили 100% в сумме
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	bid, tender, err := lockBidWithTender(tx, uint(bidID))
+	if err != nil {
+		writeLockError(w, err)
+		return
+	}
+	if !checkPermission(w, tender.OrganizationID, employee.ID, validators.ActionCloseTender, "У вас нет прав присуждать тендер.") {
+		return
+	}
+	if !checkIfMatch(w, r, bid.Version) {
+		return
+	}
+	if bid.Status != models.PUBLISHEDBid {
+		http.Error(w, "Долю можно задать только победившему предложению", http.StatusBadRequest)
+		return
+	}
+
+	// Доли распределяются между победителями тендера, а в тендере с лотами — внутри лота
+	query := tx.Model(&models.Bid{}).
+		Where("tender_id = ? AND status = ? AND id <> ?", tender.ID, models.PUBLISHEDBid, bid.ID)
+	if bid.LotID != nil {
+		query = query.Where("lot_id = ?", *bid.LotID)
+	} else {
+		query = query.Where("lot_id IS NULL")
+	}
+	var awarded decimal.NullDecimal
+	if err := query.Select("SUM(award_share)").Row().Scan(&awarded); err != nil {
+		http.Error(w, "Ошибка подсчета долей победителей", http.StatusInternalServerError)
+		return
+	}
+	if err := validators.CheckAwardShare(request.Share, awarded.Decimal); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	bid.AwardShare = request.Share
+	bid.AwardQuantity = request.Quantity
+	bid.Version++
+	if err := tx.Save(&bid).Error; err != nil {
+		log.Println("Ошибка сохранения доли победителя:", err)
+		http.Error(w, "Ошибка сохранения доли", http.StatusInternalServerError)
+		return
+	}
+	if err := saveBidsVersion(tx, bid, &employee.ID); err != nil {
+		http.Error(w, "Ошибка сохранения версии предложения", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка сохранения доли", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, bid)
+}
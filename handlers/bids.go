@@ -2,29 +2,42 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/gorilla/mux"
+	"gorm.io/gorm"
 	"net/http"
 	"strconv"
 	"testAvito/models"
 	"testAvito/utils"
+	"testAvito/validators"
+	"time"
 
+	"github.com/shopspring/decimal"
 	_ "github.com/swaggo/http-swagger"
 	_ "testAvito/docs"
 )
 
 // CreateBidHandler создает новое предложение (Bid).
 // @Summary Создание нового предложения
-// @Description Создает новое предложение для тендера, проверяет условия и права автора предложения.
+// @Description Создает новое предложение для тендера, проверяет условия, срок подачи предложений и права автора предложения.
 // @Tags Bids
 // @Accept  json
 // @Produce  json
+// @Security BearerAuth
 // @Param bid body models.Bid true "Информация о предложении"
 // @Success 200 {object} models.Bid "Успешное создание предложения"
 // @Failure 400 {string} string "Неверно введенное предложение"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Автор не приглашен к участию в тендере"
 // @Failure 404 {string} string "Тендер или пользователь не найдены"
 // @Failure 409 {string} string "Организация не может отправить предложение на свои тендеры"
 // @Router /bids/new [post]
 func CreateBidHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
 	var bid models.Bid
 	// Декодируем входящий json
 	if err := json.NewDecoder(r.Body).Decode(&bid); err != nil {
@@ -32,25 +45,46 @@ func CreateBidHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Проверка на существование тендера
-	var tender models.Tender
-	if err := utils.DB.First(&tender, bid.TenderID).Error; err != nil {
-		http.Error(w, "Тендер не найден.", http.StatusNotFound)
+	// Проверка на существование тендера, он блокируется до конца транзакции, чтобы его не закрыли во время подачи
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, err := lockTender(tx, bid.TenderID)
+	if err != nil {
+		writeLockError(w, err)
 		return
 	}
 	if tender.Status != models.PUBLISHED {
 		http.Error(w, "Тендер не опубликован.", http.StatusNotFound)
 		return
 	}
-	switch bid.AuthorType {
-	case models.USER:
-		var employee models.Employee
-		if err := utils.DB.First(&employee, bid.AuthorID).Error; err != nil {
-			http.Error(w, "Пользователь не найден.", http.StatusNotFound)
+	if tender.SubmissionDeadline != nil && !time.Now().Before(*tender.SubmissionDeadline) {
+		http.Error(w, "Срок подачи предложений по тендеру истек.", http.StatusBadRequest)
+		return
+	}
+	lot, err := bidLot(tender, bid.LotID)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckLotBidAmount(bid.Amount, lot, tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if tender.Type == models.AUCTION {
+		if !auctionRunning(tender) {
+			http.Error(w, "Аукцион завершен, новые предложения не принимаются.", http.StatusBadRequest)
 			return
 		}
-		var orgResp models.OrganizationResponsible
-		if err := utils.DB.Where("organization_id = ? AND user_id = ?", tender.OrganizationID, employee.ID).First(&orgResp).Error; err == nil {
+		if !bid.Amount.Valid {
+			http.Error(w, "Для участия в аукционе необходимо указать сумму amount.", http.StatusBadRequest)
+			return
+		}
+	}
+	switch bid.AuthorType {
+	case models.USER:
+		// Пользователь может подать предложение только от своего имени
+		bid.AuthorID = employee.ID
+		if responsible, _ := validators.CheckOrganizationResponsible(tender.OrganizationID, employee.ID); responsible {
 			http.Error(w, "Пользователь не может подать предложение на тендер в своей организации.", http.StatusBadRequest)
 			return
 		}
@@ -65,21 +99,56 @@ func CreateBidHandler(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Организация не может отправлять себе же предложения на свои тендеры.", http.StatusBadRequest)
 			return
 		}
+		if !checkPermission(w, org.ID, employee.ID, validators.ActionManageBid, "Только члены организации могут подавать предложения от ее имени.") {
+			return
+		}
 	default:
 		http.Error(w, "Неверно введенный тип автора. Тип автора должен быть USER или ORGANIZATION.", http.StatusBadRequest)
 		return
 
 	}
 
+	// В тендер по приглашениям предложения подают только приглашенные
+	if tender.Visibility == models.INVITEONLYVisibility && !bidAuthorInvited(tender, bid) {
+		http.Error(w, "Автор предложения не приглашен к участию в тендере.", http.StatusForbidden)
+		return
+	}
+
 	// Установление статуса создания предложения
 	bid.Status = models.CREATEDBid
+	bid.NeedsReconfirmation = false
+	// Долю и объем назначает организатор после победы предложения
+	bid.AwardShare = decimal.NullDecimal{}
+	bid.AwardQuantity = decimal.NullDecimal{}
 
 	// Создание в бд предложения
-	if err := utils.DB.Create(&bid).Error; err != nil {
+	if err := tx.Create(&bid).Error; err != nil {
 		http.Error(w, "Ошибка создания предложения.", http.StatusNotFound)
 		return
 	}
-	saveBidsVersion(bid)
+	if err := saveBidsVersion(tx, bid, &employee.ID); err != nil {
+		http.Error(w, "Ошибка сохранения версии предложения.", http.StatusInternalServerError)
+		return
+	}
+
+	// Новое предложение с лучшей ценой считается снижением цены в текущем раунде аукциона
+	if tender.Type == models.AUCTION {
+		best, err := bestAuctionPrice(tx, tender.ID, bid.ID)
+		if err != nil {
+			http.Error(w, "Ошибка определения лучшей цены аукциона.", http.StatusInternalServerError)
+			return
+		}
+		if !best.Valid || validators.CheckAuctionPrice(bid.Amount.Decimal, best, tender) == nil {
+			if err := registerAuctionBid(tx, &tender); err != nil {
+				http.Error(w, "Ошибка обновления раунда аукциона.", http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка создания предложения.", http.StatusInternalServerError)
+		return
+	}
 	// Возвращаем все в нормальный вид (unmarshal)
 	utils.JSONFormat(w, r, bid)
 
@@ -87,25 +156,18 @@ func CreateBidHandler(w http.ResponseWriter, r *http.Request) {
 
 // GetBidUserHandler получает список предложений пользователя.
 // @Summary Получение предложений пользователя
-// @Description Возвращает список предложений, созданных пользователем с указанным именем (username).
+// @Description Возвращает список предложений, созданных авторизованным пользователем.
 // @Tags Bids
 // @Accept  json
 // @Produce  json
-// @Param username query string true "Имя пользователя для поиска предложений"
+// @Security BearerAuth
 // @Success 200 {array} models.Bid "Список предложений пользователя"
-// @Failure 400 {string} string "Имя пользователя пустое"
-// @Failure 404 {string} string "Пользователь не найден"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 500 {string} string "Ошибка нахождения предложений"
 // @Router /bids/my [get]
 func GetBidUserHandler(w http.ResponseWriter, r *http.Request) {
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		http.Error(w, "Имя пользователя пустое", http.StatusBadRequest)
-		return
-	}
-	var employee models.Employee
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	employee, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
 
@@ -126,9 +188,11 @@ func GetBidUserHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param tenderId path int true "ID тендера"
-// @Param username query string true "Имя пользователя для проверки прав доступа"
-// @Success 200 {array} models.Bid "Список предложений"
-// @Failure 400 {string} string "Неверный тендер ID или пустое имя пользователя"
+// @Param lotId query int false "ID лота для фильтрации предложений"
+// @Security BearerAuth
+// @Success 200 {array} models.Bid "Список предложений (для закрытого тендера до вскрытия - объект models.SealedBids)"
+// @Failure 400 {string} string "Неверный тендер ID"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Нет прав на получение списка предложений"
 // @Failure 404 {string} string "Тендер или пользователь не найдены"
 // @Failure 500 {string} string "Ошибка получения предложений"
@@ -146,30 +210,37 @@ func GetBidByTenderIdHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Ошибка нахождения тендера", http.StatusInternalServerError)
 		return
 	}
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		http.Error(w, "Имя пользователя пустое", http.StatusBadRequest)
-		return
-	}
-	var employee models.Employee
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Ошибка нахождения пользователя", http.StatusInternalServerError)
+	employee, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
 
-	var organizationResponsible models.OrganizationResponsible
-	if err := utils.DB.Where("organization_id = ? AND user_id = ?", tender.OrganizationID, employee.ID).First(&organizationResponsible).Error; err != nil {
-		http.Error(w, "У вас нет прав получить список предложений для тендера.", http.StatusForbidden)
+	if !checkPermission(w, tender.OrganizationID, employee.ID, validators.ActionViewBids, "У вас нет прав получить список предложений для тендера.") {
 		return
 	}
 
-	// Создаем массив из предложений
+	// Создаем массив из предложений, при lotId — только по этому лоту
+	query := utils.DB.Where("tender_id = ?", tenderID)
+	if lotID := r.URL.Query().Get("lotId"); lotID != "" {
+		id, err := strconv.Atoi(lotID)
+		if err != nil {
+			http.Error(w, "Неверный ID лота", http.StatusBadRequest)
+			return
+		}
+		query = query.Where("lot_id = ?", id)
+	}
 	var bids []models.Bid
-	if err := utils.DB.Where("tender_id = ?", tenderID).Find(&bids).Error; err != nil {
+	if err := query.Find(&bids).Error; err != nil {
 		http.Error(w, "Ошибка нахождения предложения", http.StatusInternalServerError)
 		return
 	}
 
+	// До вскрытия закрытого тендера отдаем только количество и метаданные
+	if !bidsUnsealed(tender) {
+		utils.JSONFormat(w, r, sealedBids(tender, bids))
+		return
+	}
+
 	// Возвращаем все в нормальный вид (unmarshal) и выводим массив предложений
 	utils.JSONFormat(w, r, bids)
 }
@@ -181,9 +252,10 @@ func GetBidByTenderIdHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  plain
 // @Param bidId path int true "ID предложения"
-// @Param username query string true "Имя пользователя, запрашивающего статус"
+// @Security BearerAuth
 // @Success 200 {string} string "Статус предложения"
-// @Failure 400 {string} string "Неверный ID предложения или никнейм не введен"
+// @Failure 400 {string} string "Неверный ID предложения"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Нет прав для просмотра статуса предложения"
 // @Failure 404 {string} string "Предложение или пользователь не найдены"
 // @Failure 500 {string} string "Ошибка сервера"
@@ -196,13 +268,8 @@ func GetStatusBidHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		http.Error(w, "Никнейм пользователя, кто хочет проверить статус предложения не введено", http.StatusBadRequest)
-	}
-	var employee models.Employee
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	employee, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
 
@@ -220,9 +287,7 @@ func GetStatusBidHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 	case models.ORGANIZATION:
-		var orgResp models.OrganizationResponsible
-		if err := utils.DB.Where("organization_id = ? AND user_id = ?", bid.AuthorID, employee.ID).First(&orgResp).Error; err != nil {
-			http.Error(w, "Только члены организации могут смотреть на статус предложения", http.StatusForbidden)
+		if !checkPermission(w, bid.AuthorID, employee.ID, validators.ActionViewBids, "Только члены организации могут смотреть на статус предложения") {
 			return
 		}
 	default:
@@ -244,16 +309,20 @@ func GetStatusBidHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param bidId path int true "ID предложения"
-// @Param username query string true "Имя пользователя"
-// @Param bid body object true "Данные для обновления предложения (name, description)"
+// @Security BearerAuth
+// @Param bid body object true "Данные для обновления предложения (name, description, amount)"
+// @Param If-Match header string false "ETag версии, которую видел клиент"
 // @Success 200 {object} models.Bid "Обновленное предложение"
-// @Failure 400 {string} string "Неверный ID предложения, имя пользователя или данные предложения"
+// @Header 200 {string} ETag "Версия объекта после изменения"
+// @Failure 400 {string} string "Неверный ID предложения или данные предложения"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Нет прав для редактирования предложения"
 // @Failure 404 {string} string "Предложение или пользователь не найдены"
+// @Failure 412 {string} string "Версия устарела, объект изменен другим пользователем"
 // @Failure 500 {string} string "Ошибка сохранения предложения"
 // @Router /bids/{bidId}/edit [patch]
 func EditBidHandler(w http.ResponseWriter, r *http.Request) {
-	// Получаем bidId и username из URL
+	// Получаем bidId из URL
 	params := mux.Vars(r)
 	bidId, err := strconv.Atoi(params["bidId"])
 	if err != nil {
@@ -261,24 +330,18 @@ func EditBidHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Получаем username из строки запроса
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		http.Error(w, "Имя пользователя пустое", http.StatusBadRequest)
+	// Получаем авторизованного пользователя
+	employee, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
 
-	// Проверяем, существует ли пользователь с переданным username
-	var employee models.Employee
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Пользователь не найден", http.StatusNotFound)
-		return
-	}
-
-	// Находим предложение по bidId
-	var bid models.Bid
-	if err := utils.DB.First(&bid, bidId).Error; err != nil {
-		http.Error(w, "Предложение не найдено", http.StatusNotFound)
+	// Тендер и предложение блокируются до конца транзакции
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	bid, tender, err := lockBidWithTender(tx, uint(bidId))
+	if err != nil {
+		writeLockError(w, err)
 		return
 	}
 
@@ -290,15 +353,20 @@ func EditBidHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 	case models.ORGANIZATION:
-		var orgResp models.OrganizationResponsible
-		if err := utils.DB.Where("organization_id = ? AND user_id = ?", bid.AuthorID, employee.ID).First(&orgResp).Error; err != nil {
-			http.Error(w, "Только члены организации могут изменять предложения", http.StatusForbidden)
+		if !checkPermission(w, bid.AuthorID, employee.ID, validators.ActionManageBid, "Только члены организации могут изменять предложения") {
 			return
 		}
 	default:
 		http.Error(w, "Неверный тип автора предложения", http.StatusBadRequest)
 		return
 	}
+	if !checkIfMatch(w, r, bid.Version) {
+		return
+	}
+	if !submissionOpen(tender) {
+		http.Error(w, "Прием предложений по тендеру завершен, изменения невозможны", http.StatusBadRequest)
+		return
+	}
 
 	// Проверяем текущий статус предложения
 	if bid.Status == models.CANCELED {
@@ -312,7 +380,10 @@ func EditBidHandler(w http.ResponseWriter, r *http.Request) {
 
 	// Декодируем обновленные данные из тела запроса
 	var updatedBids map[string]interface{}
-	if err := json.NewDecoder(r.Body).Decode(&updatedBids); err != nil {
+	decoder := json.NewDecoder(r.Body)
+	// Числа читаем как json.Number, чтобы не терять точность суммы
+	decoder.UseNumber()
+	if err := decoder.Decode(&updatedBids); err != nil {
 		http.Error(w, "Неверно введенное предложение", http.StatusBadRequest)
 		return
 	}
@@ -324,18 +395,52 @@ func EditBidHandler(w http.ResponseWriter, r *http.Request) {
 	if description, ok := updatedBids["description"].(string); ok {
 		bid.Description = description
 	}
+	if amount, ok := updatedBids["amount"]; ok {
+		parsed, err := parseOptionalDecimal(amount)
+		if err != nil {
+			http.Error(w, "Неверный формат amount, ожидается число", http.StatusBadRequest)
+			return
+		}
+		if tender.Type == models.AUCTION {
+			http.Error(w, "В аукционе цена снижается только через lower_price", http.StatusBadRequest)
+			return
+		}
+		var lot *models.Lot
+		if bid.LotID != nil {
+			lot = &models.Lot{}
+			if err := tx.First(lot, *bid.LotID).Error; err != nil {
+				http.Error(w, "Лот не найден", http.StatusNotFound)
+				return
+			}
+		}
+		if err := validators.CheckLotBidAmount(parsed, lot, tender); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		bid.Amount = parsed
+	}
+
+	// Измененное предложение считается поданным на текущих условиях тендера
+	bid.NeedsReconfirmation = false
 
 	// Увеличиваем версию предложения
 	bid.Version++
 
 	// Сохраняем изменения в базе данных
-	if err := utils.DB.Save(&bid).Error; err != nil {
+	if err := tx.Save(&bid).Error; err != nil {
 		http.Error(w, "Ошибка сохранения предложения", http.StatusInternalServerError)
 		return
 	}
 
-	// Сохраняем версию предложения для истории
-	saveBidsVersion(bid)
+	// Сохраняем версию предложения для истории в той же транзакции
+	if err := saveBidsVersion(tx, bid, &employee.ID); err != nil {
+		http.Error(w, "Ошибка сохранения версии предложения", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка сохранения предложения", http.StatusInternalServerError)
+		return
+	}
 
 	// Возвращаем обновленное предложение в формате JSON
 	utils.JSONFormat(w, r, bid)
@@ -349,11 +454,15 @@ func EditBidHandler(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param bidId path int true "ID предложения"
 // @Param version path int true "Версия, к которой откатывается предложение"
-// @Param username query string true "Имя пользователя, инициирующего откат"
+// @Security BearerAuth
+// @Param If-Match header string false "ETag версии, которую видел клиент"
 // @Success 200 {object} models.Bid "Успешное откатывание предложения"
-// @Failure 400 {string} string "Неверный ID предложения, версия или имя пользователя"
+// @Header 200 {string} ETag "Версия объекта после изменения"
+// @Failure 400 {string} string "Неверный ID предложения, версия или сумма версии превышает текущую максимальную цену"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Нет прав для откатывания версии предложения"
 // @Failure 404 {string} string "Предложение, пользователь или версия не найдены"
+// @Failure 412 {string} string "Версия устарела, объект изменен другим пользователем"
 // @Failure 500 {string} string "Ошибка обновления предложения"
 // @Router /bids/{bidId}/rollback/{version} [put]
 func RollbackBidHandler(w http.ResponseWriter, r *http.Request) {
@@ -371,24 +480,18 @@ func RollbackBidHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Получаем username из строки запроса
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		http.Error(w, "Имя пользователя пустое", http.StatusBadRequest)
+	// Получаем авторизованного пользователя
+	employee, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
 
-	// Проверяем, существует ли пользователь с переданным username
-	var employee models.Employee
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Пользователь не найден", http.StatusNotFound)
-		return
-	}
-
-	// Находим предложение по bidId
-	var bid models.Bid
-	if err := utils.DB.First(&bid, bidID).Error; err != nil {
-		http.Error(w, "Предложение не найдено", http.StatusNotFound)
+	// Тендер и предложение блокируются до конца транзакции
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	bid, tender, err := lockBidWithTender(tx, uint(bidID))
+	if err != nil {
+		writeLockError(w, err)
 		return
 	}
 
@@ -400,9 +503,7 @@ func RollbackBidHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 	case models.ORGANIZATION:
-		var orgResp models.OrganizationResponsible
-		if err := utils.DB.Where("organization_id = ? AND user_id = ?", bid.AuthorID, employee.ID).First(&orgResp).Error; err != nil {
-			http.Error(w, "Только члены организации могут изменять версию предложения", http.StatusForbidden)
+		if !checkPermission(w, bid.AuthorID, employee.ID, validators.ActionManageBid, "Только члены организации могут изменять версию предложения") {
 			return
 		}
 
@@ -410,6 +511,13 @@ func RollbackBidHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Неверный тип автора предложения", http.StatusBadRequest)
 		return
 	}
+	if !checkIfMatch(w, r, bid.Version) {
+		return
+	}
+	if !submissionOpen(tender) {
+		http.Error(w, "Прием предложений по тендеру завершен, изменения невозможны", http.StatusBadRequest)
+		return
+	}
 
 	// Проверяем статус предложения
 	if bid.Status == models.CANCELED {
@@ -421,6 +529,12 @@ func RollbackBidHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// В аукционе откат мог бы поднять уже предложенную цену
+	if tender.Type == models.AUCTION {
+		http.Error(w, "Откат предложений аукциона невозможен", http.StatusBadRequest)
+		return
+	}
+
 	// Находим указанную версию предложения
 	var bidVersion models.BidVersion
 	if err := utils.DB.Where("bid_id = ? AND version = ?", bidID, version).First(&bidVersion).Error; err != nil {
@@ -428,18 +542,40 @@ func RollbackBidHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Максимальную цену тендера или лота могли снизить после сохранения версии
+	var lot *models.Lot
+	if bid.LotID != nil {
+		lot = &models.Lot{}
+		if err := tx.First(lot, *bid.LotID).Error; err != nil {
+			http.Error(w, "Лот не найден", http.StatusNotFound)
+			return
+		}
+	}
+	if err := validators.CheckLotBidAmount(bidVersion.Amount, lot, tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Откат предложения к указанной версии
 	bid.Name = bidVersion.Name
 	bid.Description = bidVersion.Description
 	bid.Status = bidVersion.Status
+	bid.Amount = bidVersion.Amount
 	bid.Version++ // Увеличиваем версию предложения
 
 	// Сохраняем изменения в базе данных
-	if err := utils.DB.Save(&bid).Error; err != nil {
+	if err := tx.Save(&bid).Error; err != nil {
+		http.Error(w, "Ошибка обновления предложения", http.StatusInternalServerError)
+		return
+	}
+	if err := saveBidsVersion(tx, bid, &employee.ID); err != nil {
+		http.Error(w, "Ошибка сохранения версии предложения", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
 		http.Error(w, "Ошибка обновления предложения", http.StatusInternalServerError)
 		return
 	}
-	saveBidsVersion(bid)
 	// Возвращаем обновленное предложение в формате JSON
 	utils.JSONFormat(w, r, bid)
 }
@@ -452,9 +588,10 @@ func RollbackBidHandler(w http.ResponseWriter, r *http.Request) {
 // @Produce  json
 // @Param bidId path int true "ID предложения"
 // @Param bidFeedback query string true "Решение по предложению (одобрено или отклонено)"
-// @Param username query string true "Имя пользователя, принимающего решение"
+// @Security BearerAuth
 // @Success 200 {object} models.BidFeedback "Отзыв успешно сохранен"
-// @Failure 400 {string} string "Неверный ID предложения или пустое имя пользователя или отзыв"
+// @Failure 400 {string} string "Неверный ID предложения или пустой отзыв"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Нет прав для принятия решения по предложению"
 // @Failure 404 {string} string "Пользователь, предложение или тендер не найдены"
 // @Failure 409 {string} string "Решение по данному предложению уже было принято"
@@ -469,21 +606,14 @@ func SubmitReviewBidByTenderIdHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	bidFeedback := r.URL.Query().Get("bidFeedback")
-	username := r.URL.Query().Get("username")
 
 	if bidFeedback == "" {
 		http.Error(w, "Необходимо ввести решение по предложению", http.StatusBadRequest)
 		return
 	}
 
-	if username == "" {
-		http.Error(w, "Имя пользователя пустое", http.StatusBadRequest)
-		return
-	}
-
-	var employee models.Employee
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	employee, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
 
@@ -499,24 +629,30 @@ func SubmitReviewBidByTenderIdHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var organizationResponsible models.OrganizationResponsible
-	if err := utils.DB.Where("organization_id = ? AND user_id = ?", tender.OrganizationID, employee.ID).First(&organizationResponsible).Error; err != nil {
-		http.Error(w, "Вы не можете принимать решение по данному предложению", http.StatusForbidden)
+	if !checkPermission(w, tender.OrganizationID, employee.ID, validators.ActionWriteFeedback, "Вы не можете принимать решение по данному предложению") {
+		return
+	}
+	if !bidsUnsealed(tender) {
+		http.Error(w, "Тендер закрытый, отзывы можно оставлять только после окончания приема предложений", http.StatusBadRequest)
 		return
 	}
 
 	var existingFeedback models.BidFeedback
-	if err := utils.DB.Where("bid_id = ? AND username = ?", bid.ID, username).First(&existingFeedback).Error; err == nil {
+	if err := utils.DB.Where("bid_id = ? AND username = ?", bid.ID, employee.Username).First(&existingFeedback).Error; err == nil {
 		http.Error(w, "Вы уже приняли решение по данному предложению", http.StatusConflict)
 		return
 	}
 	newFeedback := models.BidFeedback{
 		BidID:    bid.ID,
-		Username: username,
+		Username: employee.Username,
 		Feedback: bidFeedback,
 	}
 
 	if err := utils.DB.Create(&newFeedback).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "Вы уже приняли решение по данному предложению", http.StatusConflict)
+			return
+		}
 		http.Error(w, "Ошибка сохранения решения", http.StatusInternalServerError)
 		return
 	}
@@ -525,18 +661,22 @@ func SubmitReviewBidByTenderIdHandler(w http.ResponseWriter, r *http.Request) {
 
 // SubmitBidDecisionHandler добавляет решение по предложению (Bid) по его ID.
 // @Summary Добавление решения по предложению
-// @Description Добавляет решение ("Approved" или "Rejected") по предложению на основании прав пользователя. Проверяет наличие кворума для публикации предложения.
+// @Description Добавляет решение ("Approved" или "Rejected") по предложению на основании прав пользователя. Проверяет наличие кворума для публикации предложения, тендер закрывается, когда набрано число победителей MaxWinners.
 // @Tags Bids
 // @Accept  json
 // @Produce  json
 // @Param bidId path int true "ID предложения"
 // @Param decision query string true "Решение по предложению ('Approved' или 'Rejected')"
-// @Param username query string true "Имя пользователя, принимающего решение"
+// @Security BearerAuth
+// @Param If-Match header string false "ETag версии, которую видел клиент"
 // @Success 200 {object} models.Bid "Обновленное предложение"
-// @Failure 400 {string} string "Неверное решение, ID предложения или имя пользователя"
+// @Header 200 {string} ETag "Версия объекта после изменения"
+// @Failure 400 {string} string "Неверное решение или ID предложения"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Нет прав для принятия решения по предложению"
 // @Failure 404 {string} string "Пользователь, предложение или тендер не найдены"
 // @Failure 409 {string} string "Решение по данному предложению уже было принято"
+// @Failure 412 {string} string "Версия устарела, объект изменен другим пользователем"
 // @Failure 500 {string} string "Ошибка сохранения решения или публикации предложения"
 // @Router /bids/{bidId}/submit_decision [put]
 func SubmitBidDecisionHandler(w http.ResponseWriter, r *http.Request) {
@@ -548,31 +688,26 @@ func SubmitBidDecisionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Получаем решение и username из строки запроса
+	// Получаем решение из строки запроса
 	decision := r.URL.Query().Get("decision")
-	username := r.URL.Query().Get("username")
 
 	if decision == "" || (decision != "Approved" && decision != "Rejected") {
 		http.Error(w, "Неверное решение. Решение должно быть 'Approved' или 'Rejected'.", http.StatusBadRequest)
 		return
 	}
 
-	if username == "" {
-		http.Error(w, "Имя пользователя пустое", http.StatusBadRequest)
-		return
-	}
-
-	// Проверяем, существует ли пользователь с переданным username
-	var employee models.Employee
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	// Получаем авторизованного пользователя
+	employee, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
 
-	// Находим предложение по bidId
-	var bid models.Bid
-	if err := utils.DB.First(&bid, bidId).Error; err != nil {
-		http.Error(w, "Предложение не найдено", http.StatusNotFound)
+	// Тендер и предложение блокируются до конца транзакции, чтобы параллельные голоса не набрали кворум дважды
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	bid, tender, err := lockBidWithTender(tx, uint(bidId))
+	if err != nil {
+		writeLockError(w, err)
 		return
 	}
 	if bid.Status == models.CANCELED {
@@ -583,33 +718,61 @@ func SubmitBidDecisionHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Предложение уже утверждено, изменения невозможны", http.StatusBadRequest)
 		return
 	}
+	if bid.NeedsReconfirmation {
+		http.Error(w, "Условия тендера изменились, предложение ожидает подтверждения автором", http.StatusBadRequest)
+		return
+	}
 
-	// Находим тендер, связанный с предложением
-	var tender models.Tender
-	if err := utils.DB.First(&tender, bid.TenderID).Error; err != nil {
-		http.Error(w, "Тендер не найден", http.StatusNotFound)
+	// Проверяем, что пользователь является ответственным за организацию тендера
+	if !checkPermission(w, tender.OrganizationID, employee.ID, validators.ActionVoteBid, "Вы не можете принимать решение по данному предложению") {
 		return
 	}
-	if tender.Status == models.CLOSED {
-		http.Error(w, "Тендер был закрыт, нельзя добавить предложение.", http.StatusBadRequest)
+	if !checkIfMatch(w, r, bid.Version) {
+		return
+	}
+
+	// Предложение, оставшееся открытым у завершенного тендера, отменяется так же, как при закрытии тендера
+	if tenderFinished(tender) {
 		bid.Status = models.CANCELED
-		if err := utils.DB.Save(&bid).Error; err != nil {
+		bid.Version++
+		if err := tx.Save(&bid).Error; err != nil {
+			http.Error(w, "Ошибка обновления предложения", http.StatusInternalServerError)
+			return
+		}
+		if err := saveBidsVersion(tx, bid, nil); err != nil {
+			http.Error(w, "Ошибка сохранения версии предложения", http.StatusInternalServerError)
+			return
+		}
+		if err := tx.Commit().Error; err != nil {
 			http.Error(w, "Ошибка обновления предложения", http.StatusInternalServerError)
 			return
 		}
+		http.Error(w, "Тендер был закрыт или отменен, нельзя добавить предложение.", http.StatusBadRequest)
 		return
 	}
-
-	// Проверяем, что пользователь является ответственным за организацию тендера
-	var organizationResponsible models.OrganizationResponsible
-	if err := utils.DB.Where("organization_id = ? AND user_id = ?", tender.OrganizationID, employee.ID).First(&organizationResponsible).Error; err != nil {
-		http.Error(w, "Вы не можете принимать решение по данному предложению", http.StatusForbidden)
+	if !bidsUnsealed(tender) {
+		http.Error(w, "Тендер закрытый, голосование возможно только после окончания приема предложений", http.StatusBadRequest)
 		return
 	}
+	if tender.Type == models.AUCTION {
+		http.Error(w, "Победитель аукциона определяется автоматически по лучшей цене", http.StatusBadRequest)
+		return
+	}
+	if bid.LotID != nil {
+		var lot models.Lot
+		if err := tx.First(&lot, *bid.LotID).Error; err != nil {
+			http.Error(w, "Лот не найден", http.StatusNotFound)
+			return
+		}
+		if lot.Status != models.OPENLot {
+			http.Error(w, "По лоту уже принято решение", http.StatusBadRequest)
+			return
+		}
+	}
 
 	// Проверяем, что ответственный уже не голосовал за это предложение
 	var existingDecision models.BidDecision
-	if err := utils.DB.Where("bid_id = ? AND responsible_id = ?", bid.ID, employee.ID).First(&existingDecision).Error; err == nil {
+	if err := tx.Where("bid_id = ? AND responsible_id = ?", bid.ID, employee.ID).First(&existingDecision).Error; err == nil {
 		http.Error(w, "Вы уже приняли решение по данному предложению", http.StatusConflict)
 		return
 	}
@@ -620,7 +783,11 @@ func SubmitBidDecisionHandler(w http.ResponseWriter, r *http.Request) {
 		ResponsibleID: employee.ID,
 		Decision:      decision,
 	}
-	if err := utils.DB.Create(&newDecision).Error; err != nil {
+	if err := tx.Create(&newDecision).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "Вы уже приняли решение по данному предложению", http.StatusConflict)
+			return
+		}
 		http.Error(w, "Ошибка сохранения решения", http.StatusInternalServerError)
 		return
 	}
@@ -629,68 +796,56 @@ func SubmitBidDecisionHandler(w http.ResponseWriter, r *http.Request) {
 	if decision == "Rejected" {
 		bid.Status = models.CANCELED
 		bid.Version++
-		if err := utils.DB.Save(&bid).Error; err != nil {
+		if err := tx.Save(&bid).Error; err != nil {
+			http.Error(w, "Ошибка отмены предложения", http.StatusInternalServerError)
+			return
+		}
+		if err := saveBidsVersion(tx, bid, &employee.ID); err != nil {
+			http.Error(w, "Ошибка сохранения версии предложения", http.StatusInternalServerError)
+			return
+		}
+		if err := tx.Commit().Error; err != nil {
 			http.Error(w, "Ошибка отмены предложения", http.StatusInternalServerError)
 			return
 		}
-		saveBidsVersion(bid)
 		// Возвращаем обновленное предложение в формате JSON
 		utils.JSONFormat(w, r, bid)
 		return
 	}
 
-	// Подсчитываем количество ответственных за организацию
-	var responsibleCount int64
-	utils.DB.Model(&models.OrganizationResponsible{}).Where("organization_id = ?", tender.OrganizationID).Count(&responsibleCount)
+	// Подсчитываем количество ответственных за организацию, которые имеют право голоса
+	responsibleCount, err := validators.CountResponsiblesWithPermission(tender.OrganizationID, validators.ActionVoteBid)
+	if err != nil {
+		http.Error(w, "Ошибка подсчета ответственных", http.StatusInternalServerError)
+		return
+	}
 
-	// Кворум = min(3, количество ответственных за организацию)
-	quorum := int64(3)
-	if responsibleCount < 3 {
-		quorum = responsibleCount
+	// Кворум считается по правилу тендера, у тендеров без правила — по правилу организации
+	if tender.QuorumRule == "" {
+		if err := inheritQuorumRule(&tender); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
 	}
+	quorum := validators.QuorumSize(tender.QuorumRule, tender.QuorumValue, responsibleCount)
 
 	// Подсчитываем количество утверждений
 	var approvedCount int64
-	utils.DB.Model(&models.BidDecision{}).Where("bid_id = ? AND decision = 'Approved'", bid.ID).Count(&approvedCount)
+	if err := tx.Model(&models.BidDecision{}).Where("bid_id = ? AND decision = 'Approved'", bid.ID).Count(&approvedCount).Error; err != nil {
+		http.Error(w, "Ошибка подсчета решений", http.StatusInternalServerError)
+		return
+	}
 
 	// Если утверждений больше или равно кворуму, предложение публикуется
 	if approvedCount >= quorum {
-		bid.Status = models.PUBLISHEDBid
-		bid.Version++
-		if err := utils.DB.Save(&bid).Error; err != nil {
-			http.Error(w, "Ошибка публикации предложения", http.StatusInternalServerError)
-			return
-		}
-		saveBidsVersion(bid)
-
-		tender.Status = models.CLOSED
-		tender.Version++
-		if err := utils.DB.Save(&tender).Error; err != nil {
-			http.Error(w, "Ошибка закрытия тендера", http.StatusInternalServerError)
-			return
-		}
-		saveTenderVersion(tender)
-
-		var bids []models.Bid
-		if err := utils.DB.Where("tender_id = ?", tender.ID).Find(&bids).Error; err != nil {
-			http.Error(w, "Ошибка c поиском предложения по тендер айди", http.StatusInternalServerError)
-			return
-		}
-		if len(bids) == 0 {
-			http.Error(w, "Предложения не найдены для этого тендера.", http.StatusNotFound)
+		if err := awardBid(tx, &tender, &bid); err != nil {
+			http.Error(w, "Ошибка публикации предложения и закрытия тендера", http.StatusInternalServerError)
 			return
 		}
-		for _, bid := range bids {
-			if bid.Status != models.CANCELED && bid.Status != models.PUBLISHEDBid {
-				bid.Status = models.CANCELED
-				bid.Version++
-				saveBidsVersion(bid)
-				if err := utils.DB.Save(&bid).Error; err != nil {
-					http.Error(w, "Ошибка закрытия предложения", http.StatusInternalServerError)
-					return
-				}
-			}
-		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка сохранения решения", http.StatusInternalServerError)
+		return
 	}
 
 	// Возвращаем обновленное предложение в формате JSON
@@ -704,38 +859,41 @@ func SubmitBidDecisionHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  json
 // @Param bidId path int true "ID предложения"
-// @Param username query string true "Имя пользователя, изменяющего статус"
+// @Security BearerAuth
 // @Param status query string true "Новый статус предложения ('CANCELED')"
+// @Param If-Match header string false "ETag версии, которую видел клиент"
 // @Success 200 {object} models.Bid "Обновленное предложение"
-// @Failure 400 {string} string "Неверный статус, ID предложения или имя пользователя"
+// @Header 200 {string} ETag "Версия объекта после изменения"
+// @Failure 400 {string} string "Неверный статус или ID предложения"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Нет прав для изменения статуса предложения"
 // @Failure 404 {string} string "Предложение, тендер или пользователь не найдены"
+// @Failure 412 {string} string "Версия устарела, объект изменен другим пользователем"
 // @Failure 500 {string} string "Ошибка обновления статуса"
 // @Router /bids/{bidId}/status [put]
 func SetStatusBidHandler(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
 
-	bidId := params["bidId"]
-	username := r.URL.Query().Get("username")
-	status := r.URL.Query().Get("status")
-
-	var bid models.Bid
-	if err := utils.DB.First(&bid, bidId).Error; err != nil {
-		http.Error(w, "Предложение не найдено", http.StatusNotFound)
+	bidId, err := strconv.Atoi(params["bidId"])
+	if err != nil {
+		http.Error(w, "Неверный ID предложения", http.StatusBadRequest)
 		return
 	}
+	status := r.URL.Query().Get("status")
 
-	var tender models.Tender
-	if err := utils.DB.First(&tender, bid.TenderID).Error; err != nil {
-		http.Error(w, "Тендер не найден", http.StatusNotFound)
+	employee, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
-	var employee models.Employee
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+
+	// Тендер и предложение блокируются до конца транзакции
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	bid, _, err := lockBidWithTender(tx, uint(bidId))
+	if err != nil {
+		writeLockError(w, err)
 		return
 	}
-
 	switch bid.AuthorType {
 	case models.USER:
 		if bid.AuthorID != employee.ID {
@@ -744,9 +902,7 @@ func SetStatusBidHandler(w http.ResponseWriter, r *http.Request) {
 		}
 
 	case models.ORGANIZATION:
-		var orgResp models.OrganizationResponsible
-		if err := utils.DB.Where("organization_id = ? AND user_id = ?", bid.AuthorID, employee.ID).First(&orgResp).Error; err != nil {
-			http.Error(w, "Только члены организации могут изменять статус предложения", http.StatusForbidden)
+		if !checkPermission(w, bid.AuthorID, employee.ID, validators.ActionManageBid, "Только члены организации могут изменять статус предложения") {
 			return
 		}
 
@@ -754,6 +910,9 @@ func SetStatusBidHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Неверный тип автора предложения", http.StatusBadRequest)
 		return
 	}
+	if !checkIfMatch(w, r, bid.Version) {
+		return
+	}
 
 	if bid.Status == models.CANCELED {
 		http.Error(w, "Предложение отменено, дальнейшее взаимодействие с ним невозможно.", http.StatusBadRequest)
@@ -785,26 +944,34 @@ func SetStatusBidHandler(w http.ResponseWriter, r *http.Request) {
 		bid.Version++
 	}
 
-	if err := utils.DB.Save(&bid).Error; err != nil {
+	if err := tx.Save(&bid).Error; err != nil {
+		http.Error(w, "Ошибка обновления статуса предложения", http.StatusInternalServerError)
+		return
+	}
+	if err := saveBidsVersion(tx, bid, &employee.ID); err != nil {
+		http.Error(w, "Ошибка сохранения версии предложения", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
 		http.Error(w, "Ошибка обновления статуса предложения", http.StatusInternalServerError)
 		return
 	}
-	saveBidsVersion(bid)
 
 	utils.JSONFormat(w, r, bid)
 }
 
 // GetBidReviewsHandler получает отзывы по предложениям пользователя для конкретного тендера.
 // @Summary Получение отзывов по предложениям пользователя
-// @Description Возвращает отзывы по предложениям автора (authorUsername), связанным с тендером, если пользователь-запросчик (requesterUsername) имеет права доступа.
+// @Description Возвращает отзывы по предложениям автора (authorUsername), связанным с тендером, если авторизованный пользователь имеет права доступа.
 // @Tags Bids
 // @Accept  json
 // @Produce  json
 // @Param tenderId path int true "ID тендера"
 // @Param authorUsername query string true "Имя пользователя, автора предложений"
-// @Param requesterUsername query string true "Имя пользователя, запрашивающего данные"
-// @Success 200 {array} models.BidFeedback "Список отзывов по предложениям"
-// @Failure 400 {string} string "Неверный ID тендера или отсутствует authorUsername/requesterUsername"
+// @Security BearerAuth
+// @Success 200 {array} models.BidFeedback "Список отзывов по предложениям (для закрытого тендера до вскрытия - объект models.SealedBids)"
+// @Failure 400 {string} string "Неверный ID тендера или отсутствует authorUsername"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Нет доступа к просмотру обратной связи"
 // @Failure 404 {string} string "Тендер или пользователь не найден, или нет предложений"
 // @Failure 500 {string} string "Ошибка загрузки данных"
@@ -818,19 +985,17 @@ func GetBidReviewsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Получаем authorUsername и requesterUsername из строки запроса
+	// Получаем authorUsername из строки запроса
 	authorUsername := r.URL.Query().Get("authorUsername")
-	requesterUsername := r.URL.Query().Get("requesterUsername")
 
-	if authorUsername == "" || requesterUsername == "" {
-		http.Error(w, "Необходимы authorUsername и requesterUsername", http.StatusBadRequest)
+	if authorUsername == "" {
+		http.Error(w, "Необходим authorUsername", http.StatusBadRequest)
 		return
 	}
 
-	// Проверяем, существует ли пользователь-запросчик (requesterUsername)
-	var requester models.Employee
-	if err := utils.DB.Where("username = ?", requesterUsername).First(&requester).Error; err != nil {
-		http.Error(w, "Пользователь-запросчик не найден", http.StatusNotFound)
+	// Запросчиком считается авторизованный пользователь
+	requester, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
 
@@ -841,9 +1006,18 @@ func GetBidReviewsHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var organizationResponsible models.OrganizationResponsible
-	if err := utils.DB.Where("organization_id = ? AND user_id = ?", tender.OrganizationID, requester.ID).First(&organizationResponsible).Error; err != nil {
-		http.Error(w, "У вас нет доступа к просмотру обратной связи по данному предложению", http.StatusForbidden)
+	if !checkPermission(w, tender.OrganizationID, requester.ID, validators.ActionReadReviews, "У вас нет доступа к просмотру обратной связи по данному предложению") {
+		return
+	}
+
+	// До вскрытия закрытого тендера нельзя даже узнать, подавал ли автор предложение
+	if !bidsUnsealed(tender) {
+		var tenderBids []models.Bid
+		if err := utils.DB.Where("tender_id = ?", tender.ID).Find(&tenderBids).Error; err != nil {
+			http.Error(w, "Ошибка загрузки предложений тендера", http.StatusInternalServerError)
+			return
+		}
+		utils.JSONFormat(w, r, sealedBids(tender, tenderBids))
 		return
 	}
 
@@ -881,8 +1055,91 @@ func GetBidReviewsHandler(w http.ResponseWriter, r *http.Request) {
 	utils.JSONFormat(w, r, reviews)
 }
 
-// Функция для хранения версий предложений
-func saveBidsVersion(bid models.Bid) {
+// Признает предложение победившим и закрывает тендер (или его лот), отменяя остальные предложения.
+// Общий путь для кворума голосования и завершения аукциона.
+func awardBid(tx *gorm.DB, tender *models.Tender, bid *models.Bid) error {
+	bid.Status = models.PUBLISHEDBid
+	bid.Version++
+	if err := tx.Save(bid).Error; err != nil {
+		return err
+	}
+	if err := saveBidsVersion(tx, *bid, nil); err != nil {
+		return err
+	}
+
+	// В тендере с лотами присуждается только лот, тендер закрывается после решения по всем лотам
+	if bid.LotID != nil {
+		return awardLot(tx, tender, *bid)
+	}
+	// Тендер с несколькими победителями остается открытым, пока их не наберется нужное число
+	var winners int64
+	if err := tx.Model(&models.Bid{}).Where("tender_id = ? AND status = ?", tender.ID, models.PUBLISHEDBid).
+		Count(&winners).Error; err != nil {
+		return err
+	}
+	if winners < int64(tender.MaxWinners) {
+		return nil
+	}
+	if err := closeTender(tx, tender); err != nil {
+		return err
+	}
+	if err := tx.Save(tender).Error; err != nil {
+		return err
+	}
+	return saveTenderVersion(tx, *tender, nil)
+}
+
+// Автор предложения есть в списке приглашенных: сотрудник лично или через свою организацию, организация — напрямую
+func bidAuthorInvited(tender models.Tender, bid models.Bid) bool {
+	switch bid.AuthorType {
+	case models.USER:
+		return tenderInvitesEmployee(tender, bid.AuthorID)
+	case models.ORGANIZATION:
+		return tenderInvitesOrganization(tender, bid.AuthorID)
+	default:
+		return false
+	}
+}
+
+// Предложения закрытого тендера вскрываются после срока подачи или завершения тендера
+func bidsUnsealed(tender models.Tender) bool {
+	if !tender.Sealed || tenderFinished(tender) {
+		return true
+	}
+	return tender.SubmissionDeadline != nil && !time.Now().Before(*tender.SubmissionDeadline)
+}
+
+// Прием предложений открыт: тендер опубликован и срок подачи не истек. После срока предложения
+// не меняются, в том числе вскрытые предложения закрытого тендера
+func submissionOpen(tender models.Tender) bool {
+	return tender.Status == models.PUBLISHED &&
+		(tender.SubmissionDeadline == nil || time.Now().Before(*tender.SubmissionDeadline))
+}
+
+// Оставляет от предложений закрытого тендера только количество и метаданные
+func sealedBids(tender models.Tender, bids []models.Bid) models.SealedBids {
+	metadata := make([]models.BidMetadata, 0, len(bids))
+	for _, bid := range bids {
+		metadata = append(metadata, models.BidMetadata{
+			ID:        bid.ID,
+			TenderID:  bid.TenderID,
+			LotID:     bid.LotID,
+			Status:    bid.Status,
+			Version:   bid.Version,
+			CreatedAt: bid.CreatedAt,
+			UpdatedAt: bid.UpdatedAt,
+		})
+	}
+	return models.SealedBids{
+		Sealed:   true,
+		OpensAt:  tender.SubmissionDeadline,
+		Count:    len(bids),
+		Metadata: metadata,
+	}
+}
+
+// Функция для хранения версий предложений, changedBy — автор изменения, nil для изменений самой системы
+func saveBidsVersion(tx *gorm.DB, bid models.Bid, changedBy *uint) error {
 	version := models.BidVersion{
 		BidID:       bid.ID,
 		Name:        bid.Name,
@@ -891,9 +1148,10 @@ func saveBidsVersion(bid models.Bid) {
 		TenderID:    bid.TenderID,
 		AuthorID:    bid.AuthorID,
 		AuthorType:  bid.AuthorType,
+		Amount:      bid.Amount,
 		Version:     bid.Version,
-		CreatedAt:   bid.CreatedAt,
+		ChangedBy:   changedBy,
 	}
 
-	utils.DB.Create(&version)
+	return tx.Create(&version).Error
 }
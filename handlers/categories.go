@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"testAvito/models"
+	"testAvito/utils"
+
+	"gorm.io/gorm"
+)
+
+// GetServiceCategoriesHandler возвращает справочник категорий услуг.
+// @Summary Справочник категорий услуг
+// @Description Возвращает категории услуг, на которые ссылается поле ServiceType тендера. С параметром parentCode возвращает только прямые подкатегории, с пустым parentCode — категории верхнего уровня.
+// @Tags Categories
+// @Produce  json
+// @Param parentCode query string false "Код родительской категории"
+// @Success 200 {array} models.ServiceCategory "Список категорий"
+// @Failure 500 {string} string "Ошибка загрузки категорий"
+// @Router /categories [get]
+func GetServiceCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	query := utils.DB.Order("code")
+	if r.URL.Query().Has("parentCode") {
+		if parentCode := strings.TrimSpace(r.URL.Query().Get("parentCode")); parentCode != "" {
+			query = query.Where("parent_code = ?", parentCode)
+		} else {
+			query = query.Where("parent_code IS NULL")
+		}
+	}
+
+	var categories []models.ServiceCategory
+	if err := query.Find(&categories).Error; err != nil {
+		http.Error(w, "Ошибка загрузки категорий", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, categories)
+}
+
+// Оставляет в выборке тендеры указанных категорий и всех их подкатегорий
+func tendersInCategory(query *gorm.DB, codes ...string) *gorm.DB {
+	return query.Where(`service_type IN (
+		WITH RECURSIVE subtree AS (
+			SELECT code FROM service_categories WHERE code IN ?
+			UNION
+			SELECT c.code FROM service_categories c JOIN subtree s ON c.parent_code = s.code
+		)
+		SELECT code FROM subtree)`, codes)
+}
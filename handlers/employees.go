@@ -0,0 +1,292 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"log"
+	"net/http"
+	"strconv"
+	"testAvito/config"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// EmployeeRequest данные для создания или изменения сотрудника
+type EmployeeRequest struct {
+	Username  *string `json:"username"`
+	FirstName *string `json:"firstName"`
+	LastName  *string `json:"lastName"`
+	Password  *string `json:"password"`
+}
+
+// CreateEmployeeHandler создает нового сотрудника.
+// @Summary Создание сотрудника
+// @Description Создает нового сотрудника с уникальным именем пользователя и паролем для входа. Заводить сотрудников могут владельцы (OWNER) организаций и администраторы из ADMIN_USERNAMES. Первый сотрудник создается в базе напрямую: INSERT INTO employee (username, password_hash) VALUES ('admin', crypt('secret', gen_salt('bf'))) (расширение pgcrypto), его имя указывается в ADMIN_USERNAMES.
+// @Tags Employees
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param employee body EmployeeRequest true "Данные сотрудника"
+// @Success 200 {object} models.Employee "Созданный сотрудник"
+// @Failure 400 {string} string "Неверные данные сотрудника"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на создание сотрудников"
+// @Failure 500 {string} string "Ошибка создания сотрудника"
+// @Router /employees/new [post]
+func CreateEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	requester, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	if !canCreateEmployees(w, requester) {
+		return
+	}
+
+	var request EmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные", http.StatusBadRequest)
+		return
+	}
+	if request.Username == nil || request.Password == nil {
+		http.Error(w, "Необходимо ввести имя пользователя и пароль", http.StatusBadRequest)
+		return
+	}
+
+	var employee models.Employee
+	if err := applyEmployeeRequest(&employee, request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := utils.DB.Create(&employee).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "Пользователь с таким именем уже существует", http.StatusBadRequest)
+			return
+		}
+		log.Println("Ошибка создания сотрудника:", err)
+		http.Error(w, "Ошибка создания сотрудника", http.StatusInternalServerError)
+		return
+	}
+
+	utils.JSONFormat(w, r, employee)
+}
+
+// Проверяет, что сотрудник может заводить новых сотрудников, при отказе сам отвечает клиенту
+func canCreateEmployees(w http.ResponseWriter, requester models.Employee) bool {
+	for _, username := range config.AdminUsernames() {
+		if requester.Username == username {
+			return true
+		}
+	}
+	owner, err := validators.IsOrganizationOwner(requester.ID)
+	if err != nil {
+		http.Error(w, "Ошибка проверки прав", http.StatusInternalServerError)
+		return false
+	}
+	if !owner {
+		http.Error(w, "Создавать сотрудников могут только владельцы организаций и администраторы", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// EmployeeShowHandler возвращает список сотрудников.
+// @Summary Получение списка сотрудников
+// @Description Возвращает список всех сотрудников.
+// @Tags Employees
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {array} models.Employee "Список сотрудников"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 500 {string} string "Ошибка загрузки сотрудников"
+// @Router /employees [get]
+func EmployeeShowHandler(w http.ResponseWriter, r *http.Request) {
+	if _, ok := currentEmployee(w, r); !ok {
+		return
+	}
+
+	var employees []models.Employee
+	if err := utils.DB.Order("id").Find(&employees).Error; err != nil {
+		http.Error(w, "Ошибка загрузки сотрудников", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, employees)
+}
+
+// GetEmployeeHandler возвращает сотрудника по его ID.
+// @Summary Получение сотрудника
+// @Description Возвращает данные сотрудника по его ID.
+// @Tags Employees
+// @Produce  json
+// @Security BearerAuth
+// @Param employeeId path int true "ID сотрудника"
+// @Success 200 {object} models.Employee "Сотрудник"
+// @Failure 400 {string} string "Неверный ID сотрудника"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 404 {string} string "Сотрудник не найден"
+// @Router /employees/{employeeId} [get]
+func GetEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	if _, ok := currentEmployee(w, r); !ok {
+		return
+	}
+
+	employee, ok := findEmployee(w, r)
+	if !ok {
+		return
+	}
+	utils.JSONFormat(w, r, employee)
+}
+
+// EditEmployeeHandler редактирует сотрудника по его ID.
+// @Summary Редактирование сотрудника
+// @Description Обновляет имя пользователя, имя, фамилию или пароль. Сотрудник может изменять только свои данные.
+// @Tags Employees
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param employeeId path int true "ID сотрудника"
+// @Param employee body EmployeeRequest true "Данные для обновления сотрудника"
+// @Success 200 {object} models.Employee "Обновленный сотрудник"
+// @Failure 400 {string} string "Неверные данные или ID сотрудника"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение сотрудника"
+// @Failure 404 {string} string "Сотрудник не найден"
+// @Failure 500 {string} string "Ошибка обновления сотрудника"
+// @Router /employees/{employeeId}/edit [patch]
+func EditEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	requester, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	employee, ok := findEmployee(w, r)
+	if !ok {
+		return
+	}
+	if employee.ID != requester.ID {
+		http.Error(w, "Сотрудник может изменять только свои данные", http.StatusForbidden)
+		return
+	}
+
+	var request EmployeeRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные", http.StatusBadRequest)
+		return
+	}
+	if err := applyEmployeeRequest(&employee, request); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := utils.DB.Save(&employee).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "Пользователь с таким именем уже существует", http.StatusBadRequest)
+			return
+		}
+		log.Println("Ошибка обновления сотрудника:", err)
+		http.Error(w, "Ошибка обновления сотрудника", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, employee)
+}
+
+// DeleteEmployeeHandler удаляет сотрудника по его ID.
+// @Summary Удаление сотрудника
+// @Description Удаляет сотрудника и его связи с организациями. Сотрудник может удалить только себя. Последний владелец организации сначала должен передать владение другому ответственному.
+// @Tags Employees
+// @Produce  json
+// @Security BearerAuth
+// @Param employeeId path int true "ID сотрудника"
+// @Success 200 {object} models.Employee "Удаленный сотрудник"
+// @Failure 400 {string} string "Неверный ID сотрудника"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на удаление сотрудника"
+// @Failure 404 {string} string "Сотрудник не найден"
+// @Failure 409 {string} string "Сотрудник последний владелец организации"
+// @Failure 500 {string} string "Ошибка удаления сотрудника"
+// @Router /employees/{employeeId} [delete]
+func DeleteEmployeeHandler(w http.ResponseWriter, r *http.Request) {
+	requester, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	employee, ok := findEmployee(w, r)
+	if !ok {
+		return
+	}
+	if employee.ID != requester.ID {
+		http.Error(w, "Сотрудник может удалить только себя", http.StatusForbidden)
+		return
+	}
+
+	// Организации не должны остаться без владельца
+	var ownerLinks []models.OrganizationResponsible
+	if err := utils.DB.Where("user_id = ? AND role = ?", employee.ID, models.OWNER).Find(&ownerLinks).Error; err != nil {
+		http.Error(w, "Ошибка загрузки связей сотрудника с организациями", http.StatusInternalServerError)
+		return
+	}
+	for _, link := range ownerLinks {
+		if !hasAnotherOwner(w, link) {
+			return
+		}
+	}
+
+	if err := utils.DB.Where("user_id = ?", employee.ID).Delete(&models.OrganizationResponsible{}).Error; err != nil {
+		http.Error(w, "Ошибка удаления связей сотрудника с организациями", http.StatusInternalServerError)
+		return
+	}
+	if err := utils.DB.Delete(&employee).Error; err != nil {
+		http.Error(w, "Ошибка удаления сотрудника", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, employee)
+}
+
+// Ищет сотрудника по employeeId из URL, при ошибке сам отвечает клиенту
+func findEmployee(w http.ResponseWriter, r *http.Request) (models.Employee, bool) {
+	employeeID, err := strconv.Atoi(mux.Vars(r)["employeeId"])
+	if err != nil {
+		http.Error(w, "Неверный ID сотрудника", http.StatusBadRequest)
+		return models.Employee{}, false
+	}
+
+	var employee models.Employee
+	if err := utils.DB.First(&employee, employeeID).Error; err != nil {
+		http.Error(w, "Сотрудник не найден", http.StatusNotFound)
+		return models.Employee{}, false
+	}
+	return employee, true
+}
+
+// Переносит переданные поля в сотрудника с проверкой имени пользователя
+func applyEmployeeRequest(employee *models.Employee, request EmployeeRequest) error {
+	if request.Username != nil {
+		if err := validators.CheckUsernameFree(*request.Username, employee.ID); err != nil {
+			return err
+		}
+		employee.Username = *request.Username
+	}
+	if request.FirstName != nil {
+		employee.FirstName = *request.FirstName
+	}
+	if request.LastName != nil {
+		employee.LastName = *request.LastName
+	}
+	if request.Password != nil {
+		if *request.Password == "" {
+			return errors.New("Пароль не может быть пустым")
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(*request.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return errors.New("Пароль не может быть длиннее 72 байт")
+		}
+		employee.PasswordHash = string(hash)
+	}
+	return nil
+}
@@ -0,0 +1,538 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"testAvito/middleware"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CriterionRequest данные критерия оценки
+type CriterionRequest struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Weight      decimal.Decimal `json:"weight" swaggertype:"string"`
+}
+
+// ScoreRequest оценки предложения по критериям
+type ScoreRequest struct {
+	Scores []CriterionScore `json:"scores"`
+}
+
+// CriterionScore оценка по одному критерию
+type CriterionScore struct {
+	CriterionID uint `json:"criterionId"`
+	Score       int  `json:"score"`
+}
+
+// CreateCriterionHandler добавляет взвешенный критерий оценки в тендер.
+// @Summary Создание критерия оценки
+// @Description Добавляет в тендер в статусе CREATED критерий оценки предложений (цена, сроки поставки, опыт и т.д.) с положительным весом.
+// @Tags Evaluation
+// @Accept  json
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Param criterion body CriterionRequest true "Данные критерия"
+// @Success 200 {object} models.EvaluationCriterion "Созданный критерий"
+// @Failure 400 {string} string "Неверные данные критерия, ID или статус тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка создания критерия"
+// @Router /tenders/{tenderId}/criteria [post]
+func CreateCriterionHandler(w http.ResponseWriter, r *http.Request) {
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, ok := findEditableCriteriaTender(w, r, tx)
+	if !ok {
+		return
+	}
+	if tender.Type == models.AUCTION {
+		http.Error(w, "Победитель аукциона определяется по цене, критерии оценки не задаются", http.StatusBadRequest)
+		return
+	}
+
+	var request CriterionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные критерия", http.StatusBadRequest)
+		return
+	}
+	criterion := models.EvaluationCriterion{
+		TenderID:    tender.ID,
+		Name:        request.Name,
+		Description: request.Description,
+		Weight:      request.Weight,
+	}
+	if err := validators.ValidateCriterion(&criterion); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := tx.Create(&criterion).Error; err != nil {
+		log.Println("Ошибка создания критерия:", err)
+		http.Error(w, "Ошибка создания критерия", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка создания критерия", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, criterion)
+}
+
+// GetTenderCriteriaHandler возвращает критерии оценки тендера.
+// @Summary Получение критериев оценки
+// @Description Возвращает взвешенные критерии, по которым оцениваются предложения тендера.
+// @Tags Evaluation
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Success 200 {array} models.EvaluationCriterion "Список критериев"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка загрузки критериев"
+// @Router /tenders/{tenderId}/criteria [get]
+func GetTenderCriteriaHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := strconv.Atoi(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "Неверный тендер ID", http.StatusBadRequest)
+		return
+	}
+
+	var tender models.Tender
+	if err := utils.DB.First(&tender, tenderID).Error; err != nil {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return
+	}
+
+	// Смотреть критерии можно и без авторизации, если тендер виден всем
+	var viewer *models.Employee
+	if employee, ok := middleware.EmployeeFromContext(r.Context()); ok {
+		viewer = &employee
+	}
+	if !canViewTender(tender, viewer) {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return
+	}
+
+	var criteria []models.EvaluationCriterion
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Order("id").Find(&criteria).Error; err != nil {
+		http.Error(w, "Ошибка загрузки критериев", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, criteria)
+}
+
+// DeleteCriterionHandler удаляет критерий оценки из неопубликованного тендера.
+// @Summary Удаление критерия оценки
+// @Description Удаляет критерий, пока тендер в статусе CREATED.
+// @Tags Evaluation
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Param criterionId path int true "ID критерия"
+// @Security BearerAuth
+// @Success 200 {object} models.EvaluationCriterion "Удаленный критерий"
+// @Failure 400 {string} string "Неверный ID или статус тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение тендера"
+// @Failure 404 {string} string "Тендер или критерий не найдены"
+// @Failure 500 {string} string "Ошибка удаления критерия"
+// @Router /tenders/{tenderId}/criteria/{criterionId} [delete]
+func DeleteCriterionHandler(w http.ResponseWriter, r *http.Request) {
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, ok := findEditableCriteriaTender(w, r, tx)
+	if !ok {
+		return
+	}
+
+	criterionID, err := strconv.Atoi(mux.Vars(r)["criterionId"])
+	if err != nil {
+		http.Error(w, "Неверный ID критерия", http.StatusBadRequest)
+		return
+	}
+	var criterion models.EvaluationCriterion
+	if err := utils.DB.Where("id = ? AND tender_id = ?", criterionID, tender.ID).First(&criterion).Error; err != nil {
+		http.Error(w, "Критерий не найден", http.StatusNotFound)
+		return
+	}
+
+	if err := tx.Delete(&criterion).Error; err != nil {
+		http.Error(w, "Ошибка удаления критерия", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка удаления критерия", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, criterion)
+}
+
+// SubmitBidScoresHandler сохраняет оценки предложения по критериям.
+// @Summary Оценка предложения по критериям
+// @Description Ответственный с правом голоса ставит предложению оценки от 0 до 10 по критериям тендера. Повторная оценка по критерию заменяет прежнюю.
+// @Tags Evaluation
+// @Accept  json
+// @Produce  json
+// @Param bidId path int true "ID предложения"
+// @Security BearerAuth
+// @Param scores body ScoreRequest true "Оценки по критериям"
+// @Success 200 {array} models.BidScore "Оценки ответственного по предложению"
+// @Failure 400 {string} string "Неверные оценки, ID предложения или статус тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав оценивать предложение"
+// @Failure 404 {string} string "Предложение или тендер не найдены"
+// @Failure 500 {string} string "Ошибка сохранения оценок"
+// @Router /bids/{bidId}/scores [put]
+func SubmitBidScoresHandler(w http.ResponseWriter, r *http.Request) {
+	bidId, err := strconv.Atoi(mux.Vars(r)["bidId"])
+	if err != nil {
+		http.Error(w, "Неверный ID предложения", http.StatusBadRequest)
+		return
+	}
+
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	var request ScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || len(request.Scores) == 0 {
+		http.Error(w, "Необходимо передать оценки по критериям", http.StatusBadRequest)
+		return
+	}
+
+	var bid models.Bid
+	if err := utils.DB.First(&bid, bidId).Error; err != nil {
+		http.Error(w, "Предложение не найдено", http.StatusNotFound)
+		return
+	}
+	if bid.Status == models.CANCELED || bid.Status == models.PUBLISHEDBid {
+		http.Error(w, "Решение по предложению уже принято, оценка невозможна", http.StatusBadRequest)
+		return
+	}
+
+	var tender models.Tender
+	if err := utils.DB.First(&tender, bid.TenderID).Error; err != nil {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return
+	}
+	if !checkPermission(w, tender.OrganizationID, employee.ID, validators.ActionVoteBid, "Вы не можете оценивать данное предложение") {
+		return
+	}
+	if tender.Status != models.PUBLISHED {
+		http.Error(w, "Оценивать можно только предложения опубликованного тендера", http.StatusBadRequest)
+		return
+	}
+	if !bidsUnsealed(tender) {
+		http.Error(w, "Тендер закрытый, оценка возможна только после окончания приема предложений", http.StatusBadRequest)
+		return
+	}
+
+	var criteria []models.EvaluationCriterion
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Find(&criteria).Error; err != nil {
+		http.Error(w, "Ошибка загрузки критериев", http.StatusInternalServerError)
+		return
+	}
+	known := make(map[uint]bool, len(criteria))
+	for _, criterion := range criteria {
+		known[criterion.ID] = true
+	}
+
+	scores := make([]models.BidScore, 0, len(request.Scores))
+	for _, score := range request.Scores {
+		if !known[score.CriterionID] {
+			http.Error(w, "Критерий "+strconv.Itoa(int(score.CriterionID))+" не относится к тендеру", http.StatusBadRequest)
+			return
+		}
+		if err := validators.CheckCriterionScore(score.Score); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		scores = append(scores, models.BidScore{
+			BidID:         bid.ID,
+			CriterionID:   score.CriterionID,
+			ResponsibleID: employee.ID,
+			Score:         score.Score,
+		})
+	}
+
+	// Повторная оценка по тому же критерию заменяет прежнюю
+	if err := utils.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bid_id"}, {Name: "criterion_id"}, {Name: "responsible_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"score", "updated_at"}),
+	}).Create(&scores).Error; err != nil {
+		log.Println("Ошибка сохранения оценок:", err)
+		http.Error(w, "Ошибка сохранения оценок", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, scores)
+}
+
+// GetTenderEvaluationHandler возвращает рейтинг предложений по взвешенным критериям.
+// @Summary Рейтинг предложений по критериям
+// @Description Возвращает действующие предложения тендера по убыванию итоговой оценки: средняя оценка по каждому критерию, взвешенная по весам критериев. Критерий без оценок считается как 0.
+// @Tags Evaluation
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Param lotId query int false "ID лота для рейтинга внутри лота"
+// @Security BearerAuth
+// @Success 200 {array} models.BidEvaluation "Рейтинг предложений"
+// @Failure 400 {string} string "Неверный ID тендера или лота, либо предложения еще не вскрыты"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр предложений"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка расчета рейтинга"
+// @Router /tenders/{tenderId}/evaluation [get]
+func GetTenderEvaluationHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findTenderForAction(w, r, validators.ActionViewBids, "У вас нет прав просматривать предложения тендера.")
+	if !ok {
+		return
+	}
+	if !bidsUnsealed(tender) {
+		http.Error(w, "Тендер закрытый, рейтинг доступен только после окончания приема предложений", http.StatusBadRequest)
+		return
+	}
+	lotID, ok := evaluationLotID(w, r)
+	if !ok {
+		return
+	}
+
+	ranking, err := evaluateBids(tender, lotID)
+	if err != nil {
+		log.Println("Ошибка расчета рейтинга:", err)
+		http.Error(w, "Ошибка расчета рейтинга", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, ranking)
+}
+
+// AwardByEvaluationHandler присуждает тендер (или лот) предложению с лучшей итоговой оценкой.
+// @Summary Присуждение по рейтингу
+// @Description Принимает лучшее по рейтингу критериев предложение из еще не победивших тем же путем, что и кворум голосования: тендер закрывается, когда набрано число победителей MaxWinners. Для тендера с лотами присуждается указанный лот.
+// @Tags Evaluation
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Param lotId query int false "ID лота (обязателен для тендера с лотами)"
+// @Security BearerAuth
+// @Success 200 {object} models.Bid "Принятое предложение"
+// @Failure 400 {string} string "Неверный ID, статус тендера или нет оцененных предложений"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на закрытие тендера"
+// @Failure 404 {string} string "Тендер или лот не найдены"
+// @Failure 409 {string} string "Предложение уже принято или отменено параллельным запросом"
+// @Failure 500 {string} string "Ошибка присуждения"
+// @Router /tenders/{tenderId}/evaluation/award [put]
+func AwardByEvaluationHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findTenderForAction(w, r, validators.ActionCloseTender, "У вас нет прав присуждать тендер.")
+	if !ok {
+		return
+	}
+	if tender.Status != models.PUBLISHED {
+		http.Error(w, "Тендер должен быть в статусе PUBLISHED", http.StatusBadRequest)
+		return
+	}
+	if tender.Type == models.AUCTION {
+		http.Error(w, "Победитель аукциона определяется автоматически по лучшей цене", http.StatusBadRequest)
+		return
+	}
+	if !bidsUnsealed(tender) {
+		http.Error(w, "Тендер закрытый, присуждение возможно только после окончания приема предложений", http.StatusBadRequest)
+		return
+	}
+	lotID, ok := evaluationLotID(w, r)
+	if !ok {
+		return
+	}
+	if _, err := bidLot(tender, lotID); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ranking, err := evaluateBids(tender, lotID)
+	if err != nil {
+		log.Println("Ошибка расчета рейтинга:", err)
+		http.Error(w, "Ошибка расчета рейтинга", http.StatusInternalServerError)
+		return
+	}
+	// Уже победившие предложения пропускаются, присуждается лучшее из оставшихся
+	best := -1
+	for i, evaluation := range ranking {
+		if evaluation.Status != models.PUBLISHEDBid {
+			best = i
+			break
+		}
+	}
+	if best < 0 || !ranking[best].Scored {
+		http.Error(w, "Нет оцененных предложений для присуждения", http.StatusBadRequest)
+		return
+	}
+
+	// Пока считался рейтинг, тендер могли закрыть или присудить, поэтому он перечитывается под блокировкой
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	if tender, err = lockTender(tx, tender.ID); err != nil {
+		writeLockError(w, err)
+		return
+	}
+	if tender.Status != models.PUBLISHED {
+		http.Error(w, "Тендер должен быть в статусе PUBLISHED", http.StatusBadRequest)
+		return
+	}
+	var bid models.Bid
+	if err := forUpdate(tx).First(&bid, ranking[best].BidID).Error; err != nil {
+		http.Error(w, "Предложение не найдено", http.StatusNotFound)
+		return
+	}
+	if bid.Status != models.CREATEDBid {
+		http.Error(w, "Предложение уже принято или отменено, запросите рейтинг заново", http.StatusConflict)
+		return
+	}
+	if bid.NeedsReconfirmation {
+		http.Error(w, "Условия тендера изменились, лучшее предложение ожидает подтверждения автором", http.StatusBadRequest)
+		return
+	}
+	if err := awardBid(tx, &tender, &bid); err != nil {
+		http.Error(w, "Ошибка публикации предложения и закрытия тендера", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка публикации предложения и закрытия тендера", http.StatusInternalServerError)
+		return
+	}
+	log.Printf("Предложение %d принято по рейтингу критериев тендера %d", bid.ID, tender.ID)
+
+	utils.JSONFormat(w, r, bid)
+}
+
+// Находит тендер, критерии которого еще можно менять (статус CREATED), и блокирует его в tx.
+// Согласование черновика сбрасывается, так как веса критериев входят в его условия
+func findEditableCriteriaTender(w http.ResponseWriter, r *http.Request, tx *gorm.DB) (models.Tender, bool) {
+	tender, ok := findTenderForAction(w, r, validators.ActionEditTender, "У вас нет прав изменять тендер.")
+	if !ok {
+		return models.Tender{}, false
+	}
+	if tender, ok = lockTenderForChange(w, tx, tender.ID); !ok {
+		return models.Tender{}, false
+	}
+	if tender.Status != models.CREATED {
+		http.Error(w, "Критерии оценки можно менять только в статусе CREATED", http.StatusBadRequest)
+		return models.Tender{}, false
+	}
+	return tender, true
+}
+
+// Разбирает необязательный lotId из строки запроса, при ошибке сам отвечает клиенту
+func evaluationLotID(w http.ResponseWriter, r *http.Request) (*uint, bool) {
+	value := r.URL.Query().Get("lotId")
+	if value == "" {
+		return nil, true
+	}
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		http.Error(w, "Неверный ID лота", http.StatusBadRequest)
+		return nil, false
+	}
+	lotID := uint(id)
+	return &lotID, true
+}
+
+// Считает рейтинг действующих предложений тендера (или лота) по взвешенным средним оценкам
+func evaluateBids(tender models.Tender, lotID *uint) ([]models.BidEvaluation, error) {
+	var criteria []models.EvaluationCriterion
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Order("id").Find(&criteria).Error; err != nil {
+		return nil, err
+	}
+
+	query := utils.DB.Where("tender_id = ? AND status <> ?", tender.ID, models.CANCELED)
+	if lotID != nil {
+		query = query.Where("lot_id = ?", *lotID)
+	}
+	var bids []models.Bid
+	if err := query.Order("created_at, id").Find(&bids).Error; err != nil {
+		return nil, err
+	}
+	if len(bids) == 0 {
+		return []models.BidEvaluation{}, nil
+	}
+
+	bidIDs := make([]uint, 0, len(bids))
+	for _, bid := range bids {
+		bidIDs = append(bidIDs, bid.ID)
+	}
+	var totals []struct {
+		BidID       uint
+		CriterionID uint
+		Sum         int64
+		Votes       int64
+	}
+	if err := utils.DB.Model(&models.BidScore{}).
+		Select("bid_id, criterion_id, SUM(score) AS sum, COUNT(*) AS votes").
+		Where("bid_id IN ?", bidIDs).
+		Group("bid_id, criterion_id").
+		Scan(&totals).Error; err != nil {
+		return nil, err
+	}
+	type key struct{ bid, criterion uint }
+	byKey := make(map[key]struct{ sum, votes int64 }, len(totals))
+	for _, total := range totals {
+		byKey[key{total.BidID, total.CriterionID}] = struct{ sum, votes int64 }{total.Sum, total.Votes}
+	}
+
+	totalWeight := decimal.Zero
+	for _, criterion := range criteria {
+		totalWeight = totalWeight.Add(criterion.Weight)
+	}
+
+	ranking := make([]models.BidEvaluation, 0, len(bids))
+	for _, bid := range bids {
+		evaluation := models.BidEvaluation{
+			BidID:      bid.ID,
+			LotID:      bid.LotID,
+			Name:       bid.Name,
+			Status:     bid.Status,
+			TotalScore: decimal.Zero,
+			Criteria:   make([]models.CriterionResult, 0, len(criteria)),
+		}
+		weighted := decimal.Zero
+		for _, criterion := range criteria {
+			result := models.CriterionResult{
+				CriterionID:  criterion.ID,
+				Name:         criterion.Name,
+				Weight:       criterion.Weight,
+				AverageScore: decimal.Zero,
+			}
+			if total, ok := byKey[key{bid.ID, criterion.ID}]; ok && total.votes > 0 {
+				result.AverageScore = decimal.NewFromInt(total.sum).Div(decimal.NewFromInt(total.votes))
+				result.Votes = total.votes
+				evaluation.Scored = true
+			}
+			weighted = weighted.Add(result.AverageScore.Mul(criterion.Weight))
+			result.AverageScore = result.AverageScore.Round(2)
+			evaluation.Criteria = append(evaluation.Criteria, result)
+		}
+		if totalWeight.IsPositive() {
+			evaluation.TotalScore = weighted.Div(totalWeight).Round(2)
+		}
+		ranking = append(ranking, evaluation)
+	}
+
+	// При равной оценке выше стоит более раннее предложение
+	sort.SliceStable(ranking, func(i, j int) bool {
+		return ranking[i].TotalScore.GreaterThan(ranking[j].TotalScore)
+	})
+	for i := range ranking {
+		ranking[i].Rank = i + 1
+	}
+	return ranking, nil
+}
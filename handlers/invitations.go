@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"log"
+	"net/http"
+	"strconv"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+)
+
+// InvitationRequest приглашаемая организация или сотрудник, указывается ровно одно из полей
+type InvitationRequest struct {
+	OrganizationID *uint   `json:"organizationId"`
+	Username       *string `json:"username"`
+}
+
+// CreateInvitationHandler приглашает организацию или сотрудника к участию в тендере.
+// @Summary Приглашение к тендеру
+// @Description Добавляет организацию или сотрудника в список приглашенных. Тендер с видимостью INVITE_ONLY видят и принимают от них предложения только приглашенные.
+// @Tags Invitations
+// @Accept  json
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Param invitation body InvitationRequest true "Организация (organizationId) или сотрудник (username)"
+// @Success 200 {object} models.TenderInvitation "Созданное приглашение"
+// @Failure 400 {string} string "Неверные данные приглашения, ID или статус тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение тендера"
+// @Failure 404 {string} string "Тендер, организация или сотрудник не найдены"
+// @Failure 409 {string} string "Приглашение уже существует"
+// @Failure 500 {string} string "Ошибка сохранения приглашения"
+// @Router /tenders/{tenderId}/invitations [post]
+func CreateInvitationHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findTenderForAction(w, r, validators.ActionEditTender, "У вас нет прав изменять тендер.")
+	if !ok {
+		return
+	}
+
+	// Список приглашенных входит в условия черновика, его изменение сбрасывает согласование
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	if tender, ok = lockTenderForChange(w, tx, tender.ID); !ok {
+		return
+	}
+	if tenderFinished(tender) {
+		http.Error(w, "Тендер был закрыт или отменен, изменения невозможны.", http.StatusBadRequest)
+		return
+	}
+
+	var request InvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные приглашения", http.StatusBadRequest)
+		return
+	}
+	if (request.OrganizationID == nil) == (request.Username == nil) {
+		http.Error(w, "Укажите ровно одно из полей: organizationId или username", http.StatusBadRequest)
+		return
+	}
+
+	invitation := models.TenderInvitation{TenderID: tender.ID}
+	duplicate := tx.Model(&models.TenderInvitation{}).Where("tender_id = ?", tender.ID)
+	if request.OrganizationID != nil {
+		if exists, _ := validators.CheckOrganizationsExist(models.Organization{ID: *request.OrganizationID}); !exists {
+			http.Error(w, "Организация не найдена", http.StatusNotFound)
+			return
+		}
+		if *request.OrganizationID == tender.OrganizationID {
+			http.Error(w, "Организация-организатор не приглашается в свой тендер", http.StatusBadRequest)
+			return
+		}
+		invitation.OrganizationID = request.OrganizationID
+		duplicate = duplicate.Where("organization_id = ?", *request.OrganizationID)
+	} else {
+		var employee models.Employee
+		if err := utils.DB.Where("username = ?", *request.Username).First(&employee).Error; err != nil {
+			http.Error(w, "Сотрудник не найден", http.StatusNotFound)
+			return
+		}
+		invitation.EmployeeID = &employee.ID
+		duplicate = duplicate.Where("employee_id = ?", employee.ID)
+	}
+
+	var count int64
+	if err := duplicate.Count(&count).Error; err != nil {
+		http.Error(w, "Ошибка проверки приглашений", http.StatusInternalServerError)
+		return
+	}
+	if count > 0 {
+		http.Error(w, "Приглашение уже существует", http.StatusConflict)
+		return
+	}
+
+	if err := tx.Create(&invitation).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "Приглашение уже существует", http.StatusConflict)
+			return
+		}
+		log.Println("Ошибка сохранения приглашения:", err)
+		http.Error(w, "Ошибка сохранения приглашения", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка сохранения приглашения", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, invitation)
+}
+
+// GetInvitationsHandler возвращает список приглашенных к тендеру.
+// @Summary Список приглашенных
+// @Description Возвращает организации и сотрудников, приглашенных к участию в тендере. Доступно ответственным за тендер.
+// @Tags Invitations
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Success 200 {array} models.TenderInvitation "Список приглашений"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка загрузки приглашений"
+// @Router /tenders/{tenderId}/invitations [get]
+func GetInvitationsHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findTenderForAction(w, r, validators.ActionViewTender, "У вас нет прав просматривать приглашения тендера.")
+	if !ok {
+		return
+	}
+
+	var invitations []models.TenderInvitation
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Order("id").Find(&invitations).Error; err != nil {
+		http.Error(w, "Ошибка загрузки приглашений", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, invitations)
+}
+
+// DeleteInvitationHandler отзывает приглашение к тендеру.
+// @Summary Отзыв приглашения
+// @Description Удаляет организацию или сотрудника из списка приглашенных. Уже поданные предложения остаются.
+// @Tags Invitations
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Param invitationId path int true "ID приглашения"
+// @Security BearerAuth
+// @Success 200 {object} models.TenderInvitation "Отозванное приглашение"
+// @Failure 400 {string} string "Неверный ID"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение тендера"
+// @Failure 404 {string} string "Тендер или приглашение не найдены"
+// @Failure 500 {string} string "Ошибка удаления приглашения"
+// @Router /tenders/{tenderId}/invitations/{invitationId} [delete]
+func DeleteInvitationHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findTenderForAction(w, r, validators.ActionEditTender, "У вас нет прав изменять тендер.")
+	if !ok {
+		return
+	}
+
+	invitationID, err := strconv.Atoi(mux.Vars(r)["invitationId"])
+	if err != nil {
+		http.Error(w, "Неверный ID приглашения", http.StatusBadRequest)
+		return
+	}
+
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	if tender, ok = lockTenderForChange(w, tx, tender.ID); !ok {
+		return
+	}
+	var invitation models.TenderInvitation
+	if err := tx.Where("id = ? AND tender_id = ?", invitationID, tender.ID).First(&invitation).Error; err != nil {
+		http.Error(w, "Приглашение не найдено", http.StatusNotFound)
+		return
+	}
+
+	if err := tx.Delete(&invitation).Error; err != nil {
+		http.Error(w, "Ошибка удаления приглашения", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка удаления приглашения", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, invitation)
+}
+
+// Организации, за которые ответственен сотрудник
+func employeeOrganizationIDs(employeeID uint) []uint {
+	var ids []uint
+	if err := utils.DB.Model(&models.OrganizationResponsible{}).Where("user_id = ?", employeeID).
+		Pluck("organization_id", &ids).Error; err != nil {
+		log.Println("Ошибка загрузки организаций сотрудника:", err)
+	}
+	return ids
+}
+
+// Сотрудник приглашен лично или через одну из своих организаций
+func tenderInvitesEmployee(tender models.Tender, employeeID uint) bool {
+	var count int64
+	utils.DB.Model(&models.TenderInvitation{}).
+		Where("tender_id = ? AND (employee_id = ? OR organization_id IN ?)", tender.ID, employeeID, employeeOrganizationIDs(employeeID)).
+		Count(&count)
+	return count > 0
+}
+
+// Организация приглашена к тендеру
+func tenderInvitesOrganization(tender models.Tender, organizationID uint) bool {
+	var count int64
+	utils.DB.Model(&models.TenderInvitation{}).
+		Where("tender_id = ? AND organization_id = ?", tender.ID, organizationID).
+		Count(&count)
+	return count > 0
+}
+
+// Оставляет в выборке только тендеры, видимые сотруднику: публичные, своих организаций и те, куда он приглашен
+func visibleTenders(query *gorm.DB, viewer *models.Employee) *gorm.DB {
+	if viewer == nil {
+		return query.Where("visibility = ?", models.PUBLICVisibility)
+	}
+	orgIDs := employeeOrganizationIDs(viewer.ID)
+	return query.Where(
+		"visibility = ? OR organization_id IN ? OR id IN (SELECT tender_id FROM tender_invitations WHERE employee_id = ? OR organization_id IN ?)",
+		models.PUBLICVisibility, orgIDs, viewer.ID, orgIDs,
+	)
+}
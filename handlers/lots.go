@@ -0,0 +1,430 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"log"
+	"net/http"
+	"strconv"
+	"testAvito/middleware"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+
+	"github.com/shopspring/decimal"
+)
+
+// LotRequest данные для создания лота
+type LotRequest struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Budget      decimal.NullDecimal `json:"budget" swaggertype:"string"`
+}
+
+// CreateLotHandler добавляет лот в тендер.
+// @Summary Создание лота
+// @Description Добавляет в тендер в статусе CREATED независимый лот со своей максимальной ценой. Предложения по тендеру с лотами подаются на конкретный лот.
+// @Tags Lots
+// @Accept  json
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Param lot body LotRequest true "Данные лота"
+// @Success 200 {object} models.Lot "Созданный лот"
+// @Failure 400 {string} string "Неверные данные лота, ID или статус тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка создания лота"
+// @Router /tenders/{tenderId}/lots [post]
+func CreateLotHandler(w http.ResponseWriter, r *http.Request) {
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, ok := findEditableLotTender(w, r, tx)
+	if !ok {
+		return
+	}
+	if tender.Type == models.AUCTION {
+		http.Error(w, "Аукцион не может проводиться по тендеру с лотами", http.StatusBadRequest)
+		return
+	}
+	if tender.MaxWinners > 1 {
+		http.Error(w, "В тендере с лотами каждый лот присуждается одному предложению, уменьшите MaxWinners до 1", http.StatusBadRequest)
+		return
+	}
+
+	var request LotRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные лота", http.StatusBadRequest)
+		return
+	}
+	lot := models.Lot{
+		TenderID:    tender.ID,
+		Name:        request.Name,
+		Description: request.Description,
+		Budget:      request.Budget,
+		Status:      models.OPENLot,
+	}
+	if err := validators.ValidateLot(&lot); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := tx.Create(&lot).Error; err != nil {
+		log.Println("Ошибка создания лота:", err)
+		http.Error(w, "Ошибка создания лота", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка создания лота", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, lot)
+}
+
+// GetTenderLotsHandler возвращает лоты тендера.
+// @Summary Получение лотов тендера
+// @Description Возвращает лоты тендера с их статусами и победившими предложениями.
+// @Tags Lots
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Success 200 {array} models.Lot "Список лотов"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка загрузки лотов"
+// @Router /tenders/{tenderId}/lots [get]
+func GetTenderLotsHandler(w http.ResponseWriter, r *http.Request) {
+	tenderID, err := strconv.Atoi(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "Неверный тендер ID", http.StatusBadRequest)
+		return
+	}
+
+	var tender models.Tender
+	if err := utils.DB.First(&tender, tenderID).Error; err != nil {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return
+	}
+
+	// Смотреть лоты можно и без авторизации, если тендер виден всем
+	var viewer *models.Employee
+	if employee, ok := middleware.EmployeeFromContext(r.Context()); ok {
+		viewer = &employee
+	}
+	if !canViewTender(tender, viewer) {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return
+	}
+
+	var lots []models.Lot
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Order("id").Find(&lots).Error; err != nil {
+		http.Error(w, "Ошибка загрузки лотов", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, lots)
+}
+
+// EditLotHandler редактирует лот тендера.
+// @Summary Редактирование лота
+// @Description Обновляет название, описание или максимальную цену лота (null снимает цену), пока тендер в статусе CREATED.
+// @Tags Lots
+// @Accept  json
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Param lotId path int true "ID лота"
+// @Security BearerAuth
+// @Param lot body object true "Данные для обновления лота (name, description, budget)"
+// @Success 200 {object} models.Lot "Обновленный лот"
+// @Failure 400 {string} string "Неверные данные лота, ID или статус тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение тендера"
+// @Failure 404 {string} string "Тендер или лот не найдены"
+// @Failure 500 {string} string "Ошибка обновления лота"
+// @Router /tenders/{tenderId}/lots/{lotId} [patch]
+func EditLotHandler(w http.ResponseWriter, r *http.Request) {
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, ok := findEditableLotTender(w, r, tx)
+	if !ok {
+		return
+	}
+	lot, ok := findLot(w, r, tender)
+	if !ok {
+		return
+	}
+
+	var updatedLot map[string]interface{}
+	decoder := json.NewDecoder(r.Body)
+	// Числа читаем как json.Number, чтобы не терять точность суммы
+	decoder.UseNumber()
+	if err := decoder.Decode(&updatedLot); err != nil {
+		http.Error(w, "Неверные данные лота", http.StatusBadRequest)
+		return
+	}
+	if name, ok := updatedLot["name"].(string); ok {
+		lot.Name = name
+	}
+	if description, ok := updatedLot["description"].(string); ok {
+		lot.Description = description
+	}
+	if budget, ok := updatedLot["budget"]; ok {
+		parsed, err := parseOptionalDecimal(budget)
+		if err != nil {
+			http.Error(w, "Неверный формат budget, ожидается число", http.StatusBadRequest)
+			return
+		}
+		lot.Budget = parsed
+	}
+	if err := validators.ValidateLot(&lot); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := tx.Save(&lot).Error; err != nil {
+		http.Error(w, "Ошибка обновления лота", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка обновления лота", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, lot)
+}
+
+// DeleteLotHandler удаляет лот из неопубликованного тендера.
+// @Summary Удаление лота
+// @Description Удаляет лот, пока тендер в статусе CREATED. После публикации лот можно только отменить.
+// @Tags Lots
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Param lotId path int true "ID лота"
+// @Security BearerAuth
+// @Success 200 {object} models.Lot "Удаленный лот"
+// @Failure 400 {string} string "Неверный ID или статус тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение тендера"
+// @Failure 404 {string} string "Тендер или лот не найдены"
+// @Failure 409 {string} string "По лоту есть предложения"
+// @Failure 500 {string} string "Ошибка удаления лота"
+// @Router /tenders/{tenderId}/lots/{lotId} [delete]
+func DeleteLotHandler(w http.ResponseWriter, r *http.Request) {
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, ok := findEditableLotTender(w, r, tx)
+	if !ok {
+		return
+	}
+	lot, ok := findLot(w, r, tender)
+	if !ok {
+		return
+	}
+
+	if err := tx.Delete(&lot).Error; err != nil {
+		if errors.Is(err, gorm.ErrForeignKeyViolated) {
+			http.Error(w, "По лоту есть предложения, удаление невозможно", http.StatusConflict)
+			return
+		}
+		http.Error(w, "Ошибка удаления лота", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка удаления лота", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, lot)
+}
+
+// CancelLotHandler отменяет лот опубликованного тендера.
+// @Summary Отмена лота
+// @Description Отменяет открытый лот и все его незавершенные предложения. Когда по всем лотам принято решение (присуждены или отменены), тендер закрывается.
+// @Tags Lots
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Param lotId path int true "ID лота"
+// @Security BearerAuth
+// @Success 200 {object} models.Lot "Отмененный лот"
+// @Failure 400 {string} string "Неверный ID, статус тендера или лота"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на закрытие тендера"
+// @Failure 404 {string} string "Тендер или лот не найдены"
+// @Failure 500 {string} string "Ошибка отмены лота"
+// @Router /tenders/{tenderId}/lots/{lotId}/cancel [put]
+func CancelLotHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findTenderForAction(w, r, validators.ActionCloseTender, "У вас нет прав отменять лоты тендера.")
+	if !ok {
+		return
+	}
+	if tender.Status != models.PUBLISHED {
+		http.Error(w, "Тендер должен быть в статусе PUBLISHED", http.StatusBadRequest)
+		return
+	}
+	lot, ok := findLot(w, r, tender)
+	if !ok {
+		return
+	}
+
+	// Тендер и лот перечитываются под блокировкой, чтобы не отменить лот, который уже присуждается
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, err := lockTender(tx, tender.ID)
+	if err != nil {
+		writeLockError(w, err)
+		return
+	}
+	if err := forUpdate(tx).First(&lot, lot.ID).Error; err != nil {
+		http.Error(w, "Лот не найден", http.StatusNotFound)
+		return
+	}
+	if tender.Status != models.PUBLISHED {
+		http.Error(w, "Тендер должен быть в статусе PUBLISHED", http.StatusBadRequest)
+		return
+	}
+	if lot.Status != models.OPENLot {
+		http.Error(w, "По лоту уже принято решение", http.StatusBadRequest)
+		return
+	}
+
+	lot.Status = models.CANCELLEDLot
+	if err := tx.Save(&lot).Error; err != nil {
+		http.Error(w, "Ошибка отмены лота", http.StatusInternalServerError)
+		return
+	}
+	if err := cancelOpenBids(tx, tender.ID, &lot.ID); err != nil {
+		http.Error(w, "Ошибка отмены предложений лота", http.StatusInternalServerError)
+		return
+	}
+	if err := closeTenderIfLotsResolved(tx, &tender); err != nil {
+		http.Error(w, "Ошибка закрытия тендера", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка отмены лота", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, lot)
+}
+
+// Находит тендер из URL и проверяет право на действие, при отказе отвечает 403 с message
+func findTenderForAction(w http.ResponseWriter, r *http.Request, action validators.Action, message string) (models.Tender, bool) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return models.Tender{}, false
+	}
+
+	tenderID, err := strconv.Atoi(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "Неверный тендер ID", http.StatusBadRequest)
+		return models.Tender{}, false
+	}
+
+	var tender models.Tender
+	if err := utils.DB.First(&tender, tenderID).Error; err != nil {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return models.Tender{}, false
+	}
+	if !checkPermission(w, tender.OrganizationID, employee.ID, action, message) {
+		return models.Tender{}, false
+	}
+	return tender, true
+}
+
+// Находит тендер, состав лотов которого еще можно менять (статус CREATED), и блокирует его в tx.
+// Согласование черновика сбрасывается, так как лоты входят в его условия
+func findEditableLotTender(w http.ResponseWriter, r *http.Request, tx *gorm.DB) (models.Tender, bool) {
+	tender, ok := findTenderForAction(w, r, validators.ActionEditTender, "У вас нет прав изменять тендер.")
+	if !ok {
+		return models.Tender{}, false
+	}
+	if tender, ok = lockTenderForChange(w, tx, tender.ID); !ok {
+		return models.Tender{}, false
+	}
+	if tender.Status != models.CREATED {
+		http.Error(w, "Лоты можно менять только в статусе CREATED", http.StatusBadRequest)
+		return models.Tender{}, false
+	}
+	return tender, true
+}
+
+// Находит лот тендера по lotId из URL, при ошибке сам отвечает клиенту
+func findLot(w http.ResponseWriter, r *http.Request, tender models.Tender) (models.Lot, bool) {
+	lotID, err := strconv.Atoi(mux.Vars(r)["lotId"])
+	if err != nil {
+		http.Error(w, "Неверный ID лота", http.StatusBadRequest)
+		return models.Lot{}, false
+	}
+
+	var lot models.Lot
+	if err := utils.DB.Where("id = ? AND tender_id = ?", lotID, tender.ID).First(&lot).Error; err != nil {
+		http.Error(w, "Лот не найден", http.StatusNotFound)
+		return models.Lot{}, false
+	}
+	return lot, true
+}
+
+// Находит лот, на который подается предложение. В тендере с лотами лот обязателен и должен быть открыт.
+func bidLot(tender models.Tender, lotID *uint) (*models.Lot, error) {
+	var lots int64
+	if err := utils.DB.Model(&models.Lot{}).Where("tender_id = ?", tender.ID).Count(&lots).Error; err != nil {
+		return nil, errors.New("Ошибка загрузки лотов тендера.")
+	}
+	if lots == 0 {
+		if lotID != nil {
+			return nil, errors.New("У тендера нет лотов, lotId указывать не нужно.")
+		}
+		return nil, nil
+	}
+	if lotID == nil {
+		return nil, errors.New("Тендер состоит из лотов, необходимо указать lotId.")
+	}
+
+	var lot models.Lot
+	if err := utils.DB.Where("id = ? AND tender_id = ?", *lotID, tender.ID).First(&lot).Error; err != nil {
+		return nil, errors.New("Лот не найден в этом тендере.")
+	}
+	if lot.Status != models.OPENLot {
+		return nil, errors.New("По лоту уже принято решение, предложения не принимаются.")
+	}
+	return &lot, nil
+}
+
+// Присуждает лот предложению, отменяет остальные предложения лота и закрывает тендер, если решены все лоты
+func awardLot(tx *gorm.DB, tender *models.Tender, bid models.Bid) error {
+	var lot models.Lot
+	if err := tx.First(&lot, *bid.LotID).Error; err != nil {
+		return err
+	}
+	lot.Status = models.AWARDEDLot
+	lot.WinnerBidID = &bid.ID
+	if err := tx.Save(&lot).Error; err != nil {
+		return err
+	}
+	if err := cancelOpenBids(tx, tender.ID, &lot.ID); err != nil {
+		return err
+	}
+	return closeTenderIfLotsResolved(tx, tender)
+}
+
+// Закрывает тендер, когда по каждому его лоту принято решение (присужден или отменен)
+func closeTenderIfLotsResolved(tx *gorm.DB, tender *models.Tender) error {
+	var openLots int64
+	if err := tx.Model(&models.Lot{}).Where("tender_id = ? AND status = ?", tender.ID, models.OPENLot).Count(&openLots).Error; err != nil {
+		return err
+	}
+	if openLots > 0 {
+		return nil
+	}
+
+	if err := closeTender(tx, tender); err != nil {
+		return err
+	}
+	if err := tx.Save(tender).Error; err != nil {
+		return err
+	}
+	if err := saveTenderVersion(tx, *tender, nil); err != nil {
+		return err
+	}
+	log.Printf("По всем лотам тендера %d принято решение, тендер закрыт", tender.ID)
+	return nil
+}
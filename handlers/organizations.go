@@ -0,0 +1,531 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"log"
+	"net/http"
+	"strconv"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+)
+
+// OrganizationRequest данные для создания или изменения организации
+type OrganizationRequest struct {
+	Name        *string                  `json:"name"`
+	Description *string                  `json:"description"`
+	Type        *models.OrganizationType `json:"type"`
+	QuorumRule  *models.QuorumRule       `json:"quorumRule"`
+	QuorumValue *int                     `json:"quorumValue"`
+	// Сколько других ответственных должны согласовать тендер перед публикацией
+	SignOffRequired *int `json:"signOffRequired"`
+}
+
+// ResponsibleRequest сотрудник, назначаемый ответственным за организацию
+type ResponsibleRequest struct {
+	Username string                 `json:"username"`
+	Role     models.ResponsibleRole `json:"role"`
+}
+
+// RoleRequest новая роль ответственного
+type RoleRequest struct {
+	Role models.ResponsibleRole `json:"role"`
+}
+
+// CreateOrganizationHandler создает новую организацию.
+// @Summary Создание организации
+// @Description Создает организацию, создатель автоматически становится ее владельцем (роль OWNER). Правило кворума quorumRule (FIXED, PERCENT, MAJORITY, UNANIMOUS) и quorumValue по умолчанию — FIXED 3.
+// @Tags Organizations
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param organization body OrganizationRequest true "Данные организации"
+// @Success 200 {object} models.Organization "Созданная организация"
+// @Failure 400 {string} string "Неверные данные организации"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 500 {string} string "Ошибка создания организации"
+// @Router /organizations/new [post]
+func CreateOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	var request OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные", http.StatusBadRequest)
+		return
+	}
+
+	var organization models.Organization
+	applyOrganizationRequest(&organization, request)
+	if err := validators.ValidateOrganization(&organization); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := utils.DB.Create(&organization).Error; err != nil {
+		log.Println("Ошибка создания организации:", err)
+		http.Error(w, "Ошибка создания организации", http.StatusInternalServerError)
+		return
+	}
+
+	responsible := models.OrganizationResponsible{
+		OrganizationID: organization.ID,
+		UserID:         employee.ID,
+		Role:           models.OWNER,
+	}
+	if err := utils.DB.Create(&responsible).Error; err != nil {
+		log.Println("Ошибка назначения ответственного:", err)
+		http.Error(w, "Ошибка назначения ответственного за организацию", http.StatusInternalServerError)
+		return
+	}
+
+	utils.JSONFormat(w, r, organization)
+}
+
+// OrganizationShowHandler возвращает список организаций.
+// @Summary Получение списка организаций
+// @Description Возвращает список всех организаций.
+// @Tags Organizations
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {array} models.Organization "Список организаций"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 500 {string} string "Ошибка загрузки организаций"
+// @Router /organizations [get]
+func OrganizationShowHandler(w http.ResponseWriter, r *http.Request) {
+	if _, ok := currentEmployee(w, r); !ok {
+		return
+	}
+
+	var organizations []models.Organization
+	if err := utils.DB.Order("id").Find(&organizations).Error; err != nil {
+		http.Error(w, "Ошибка загрузки организаций", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, organizations)
+}
+
+// GetOrganizationHandler возвращает организацию по ее ID.
+// @Summary Получение организации
+// @Description Возвращает данные организации по ее ID.
+// @Tags Organizations
+// @Produce  json
+// @Security BearerAuth
+// @Param organizationId path int true "ID организации"
+// @Success 200 {object} models.Organization "Организация"
+// @Failure 400 {string} string "Неверный ID организации"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 404 {string} string "Организация не найдена"
+// @Router /organizations/{organizationId} [get]
+func GetOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	if _, ok := currentEmployee(w, r); !ok {
+		return
+	}
+
+	organization, ok := findOrganization(w, r)
+	if !ok {
+		return
+	}
+	utils.JSONFormat(w, r, organization)
+}
+
+// EditOrganizationHandler редактирует организацию по ее ID.
+// @Summary Редактирование организации
+// @Description Обновляет название, описание, тип организации или правило кворума по умолчанию (действует для новых тендеров). Доступно только владельцам организации.
+// @Tags Organizations
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param organizationId path int true "ID организации"
+// @Param organization body OrganizationRequest true "Данные для обновления организации"
+// @Success 200 {object} models.Organization "Обновленная организация"
+// @Failure 400 {string} string "Неверные данные или ID организации"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение организации"
+// @Failure 404 {string} string "Организация не найдена"
+// @Failure 500 {string} string "Ошибка обновления организации"
+// @Router /organizations/{organizationId}/edit [patch]
+func EditOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	organization, ok := findOrganization(w, r)
+	if !ok {
+		return
+	}
+	if !checkPermission(w, organization.ID, employee.ID, validators.ActionManageOrganization, "Только владелец организации может изменять ее") {
+		return
+	}
+
+	var request OrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные", http.StatusBadRequest)
+		return
+	}
+	applyOrganizationRequest(&organization, request)
+	if err := validators.ValidateOrganization(&organization); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := utils.DB.Save(&organization).Error; err != nil {
+		log.Println("Ошибка обновления организации:", err)
+		http.Error(w, "Ошибка обновления организации", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, organization)
+}
+
+// DeleteOrganizationHandler удаляет организацию по ее ID.
+// @Summary Удаление организации
+// @Description Удаляет организацию вместе со списком ответственных. Доступно только владельцам, организацию с тендерами удалить нельзя.
+// @Tags Organizations
+// @Produce  json
+// @Security BearerAuth
+// @Param organizationId path int true "ID организации"
+// @Success 200 {object} models.Organization "Удаленная организация"
+// @Failure 400 {string} string "Неверный ID организации"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на удаление организации"
+// @Failure 404 {string} string "Организация не найдена"
+// @Failure 409 {string} string "У организации есть тендеры"
+// @Failure 500 {string} string "Ошибка удаления организации"
+// @Router /organizations/{organizationId} [delete]
+func DeleteOrganizationHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	organization, ok := findOrganization(w, r)
+	if !ok {
+		return
+	}
+	if !checkPermission(w, organization.ID, employee.ID, validators.ActionManageOrganization, "Только владелец организации может удалить ее") {
+		return
+	}
+
+	var tendersCount int64
+	if err := utils.DB.Model(&models.Tender{}).Where("organization_id = ?", organization.ID).Count(&tendersCount).Error; err != nil {
+		http.Error(w, "Ошибка поиска тендеров организации", http.StatusInternalServerError)
+		return
+	}
+	if tendersCount > 0 {
+		http.Error(w, "У организации есть тендеры, удаление невозможно", http.StatusConflict)
+		return
+	}
+
+	if err := utils.DB.Where("organization_id = ?", organization.ID).Delete(&models.OrganizationResponsible{}).Error; err != nil {
+		http.Error(w, "Ошибка удаления ответственных за организацию", http.StatusInternalServerError)
+		return
+	}
+	if err := utils.DB.Delete(&organization).Error; err != nil {
+		http.Error(w, "Ошибка удаления организации", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, organization)
+}
+
+// ResponsibleResponse ответственный за организацию и его роль
+type ResponsibleResponse struct {
+	EmployeeID uint                   `json:"employeeId"`
+	Username   string                 `json:"username"`
+	FirstName  string                 `json:"firstName"`
+	LastName   string                 `json:"lastName"`
+	Role       models.ResponsibleRole `json:"role"`
+}
+
+// GetOrganizationResponsiblesHandler возвращает ответственных за организацию.
+// @Summary Получение ответственных за организацию
+// @Description Возвращает список сотрудников, ответственных за организацию, вместе с их ролями.
+// @Tags Organizations
+// @Produce  json
+// @Security BearerAuth
+// @Param organizationId path int true "ID организации"
+// @Success 200 {array} ResponsibleResponse "Список ответственных"
+// @Failure 400 {string} string "Неверный ID организации"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 404 {string} string "Организация не найдена"
+// @Failure 500 {string} string "Ошибка загрузки ответственных"
+// @Router /organizations/{organizationId}/responsibles [get]
+func GetOrganizationResponsiblesHandler(w http.ResponseWriter, r *http.Request) {
+	if _, ok := currentEmployee(w, r); !ok {
+		return
+	}
+
+	organization, ok := findOrganization(w, r)
+	if !ok {
+		return
+	}
+
+	responsibles := []ResponsibleResponse{}
+	if err := utils.DB.Model(&models.OrganizationResponsible{}).
+		Select("employee.id AS employee_id, employee.username, employee.first_name, employee.last_name, organization_responsible.role").
+		Joins("JOIN employee ON employee.id = organization_responsible.user_id").
+		Where("organization_responsible.organization_id = ?", organization.ID).
+		Order("employee.id").Scan(&responsibles).Error; err != nil {
+		http.Error(w, "Ошибка загрузки ответственных", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, responsibles)
+}
+
+// AddOrganizationResponsibleHandler назначает сотрудника ответственным за организацию.
+// @Summary Добавление ответственного
+// @Description Назначает сотрудника ответственным за организацию с указанной ролью (по умолчанию VIEWER). Доступно только владельцам организации.
+// @Tags Organizations
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param organizationId path int true "ID организации"
+// @Param responsible body ResponsibleRequest true "Имя пользователя и роль нового ответственного"
+// @Success 200 {object} models.OrganizationResponsible "Созданная связь"
+// @Failure 400 {string} string "Неверные данные или ID организации"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение ответственных"
+// @Failure 404 {string} string "Организация или пользователь не найдены"
+// @Failure 409 {string} string "Сотрудник уже ответственен за организацию"
+// @Failure 500 {string} string "Ошибка сохранения ответственного"
+// @Router /organizations/{organizationId}/responsibles [post]
+func AddOrganizationResponsibleHandler(w http.ResponseWriter, r *http.Request) {
+	requester, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	organization, ok := findOrganization(w, r)
+	if !ok {
+		return
+	}
+	if !checkPermission(w, organization.ID, requester.ID, validators.ActionManageResponsibles, "Только владелец организации может назначать ответственных") {
+		return
+	}
+
+	var request ResponsibleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные", http.StatusBadRequest)
+		return
+	}
+	if request.Username == "" {
+		http.Error(w, "Пользователь не введен", http.StatusBadRequest)
+		return
+	}
+	if request.Role == "" {
+		request.Role = models.VIEWER
+	}
+	if err := validators.CheckCorrectResponsibleRole(request.Role); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	employee := models.Employee{Username: request.Username}
+	if _, err := validators.CheckUsername(&employee); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	if responsible, _ := validators.CheckOrganizationResponsible(organization.ID, employee.ID); responsible {
+		http.Error(w, "Сотрудник уже ответственен за организацию", http.StatusConflict)
+		return
+	}
+
+	link := models.OrganizationResponsible{
+		OrganizationID: organization.ID,
+		UserID:         employee.ID,
+		Role:           request.Role,
+	}
+	if err := utils.DB.Create(&link).Error; err != nil {
+		if errors.Is(err, gorm.ErrDuplicatedKey) {
+			http.Error(w, "Сотрудник уже ответственен за организацию", http.StatusConflict)
+			return
+		}
+		log.Println("Ошибка назначения ответственного:", err)
+		http.Error(w, "Ошибка сохранения ответственного", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, link)
+}
+
+// SetOrganizationResponsibleRoleHandler меняет роль ответственного за организацию.
+// @Summary Изменение роли ответственного
+// @Description Меняет роль ответственного. Доступно только владельцам организации, последнего владельца понизить нельзя.
+// @Tags Organizations
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param organizationId path int true "ID организации"
+// @Param employeeId path int true "ID сотрудника"
+// @Param role body RoleRequest true "Новая роль"
+// @Success 200 {object} models.OrganizationResponsible "Обновленная связь"
+// @Failure 400 {string} string "Неверная роль или ID"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение ответственных"
+// @Failure 404 {string} string "Организация или связь не найдены"
+// @Failure 409 {string} string "Нельзя понизить последнего владельца"
+// @Failure 500 {string} string "Ошибка обновления роли"
+// @Router /organizations/{organizationId}/responsibles/{employeeId} [patch]
+func SetOrganizationResponsibleRoleHandler(w http.ResponseWriter, r *http.Request) {
+	requester, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	organization, ok := findOrganization(w, r)
+	if !ok {
+		return
+	}
+	if !checkPermission(w, organization.ID, requester.ID, validators.ActionManageResponsibles, "Только владелец организации может менять роли ответственных") {
+		return
+	}
+
+	link, ok := findResponsibleLink(w, r, organization.ID)
+	if !ok {
+		return
+	}
+
+	var request RoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные", http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckCorrectResponsibleRole(request.Role); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if link.Role == models.OWNER && request.Role != models.OWNER && !hasAnotherOwner(w, link) {
+		return
+	}
+
+	link.Role = request.Role
+	if err := utils.DB.Save(&link).Error; err != nil {
+		http.Error(w, "Ошибка обновления роли", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, link)
+}
+
+// RemoveOrganizationResponsibleHandler снимает с сотрудника ответственность за организацию.
+// @Summary Удаление ответственного
+// @Description Снимает с сотрудника ответственность за организацию. Доступно только владельцам организации, последнего владельца удалить нельзя.
+// @Tags Organizations
+// @Produce  json
+// @Security BearerAuth
+// @Param organizationId path int true "ID организации"
+// @Param employeeId path int true "ID сотрудника"
+// @Success 200 {object} models.OrganizationResponsible "Удаленная связь"
+// @Failure 400 {string} string "Неверный ID организации или сотрудника"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на изменение ответственных"
+// @Failure 404 {string} string "Организация или связь не найдены"
+// @Failure 409 {string} string "Нельзя удалить последнего владельца"
+// @Failure 500 {string} string "Ошибка удаления ответственного"
+// @Router /organizations/{organizationId}/responsibles/{employeeId} [delete]
+func RemoveOrganizationResponsibleHandler(w http.ResponseWriter, r *http.Request) {
+	requester, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+
+	organization, ok := findOrganization(w, r)
+	if !ok {
+		return
+	}
+	if !checkPermission(w, organization.ID, requester.ID, validators.ActionManageResponsibles, "Только владелец организации может удалять ответственных") {
+		return
+	}
+
+	link, ok := findResponsibleLink(w, r, organization.ID)
+	if !ok {
+		return
+	}
+	if link.Role == models.OWNER && !hasAnotherOwner(w, link) {
+		return
+	}
+
+	if err := utils.DB.Delete(&link).Error; err != nil {
+		http.Error(w, "Ошибка удаления ответственного", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, link)
+}
+
+// Ищет связь ответственного по employeeId из URL, при ошибке сам отвечает клиенту
+func findResponsibleLink(w http.ResponseWriter, r *http.Request, organizationID uint) (models.OrganizationResponsible, bool) {
+	employeeID, err := strconv.Atoi(mux.Vars(r)["employeeId"])
+	if err != nil {
+		http.Error(w, "Неверный ID сотрудника", http.StatusBadRequest)
+		return models.OrganizationResponsible{}, false
+	}
+
+	var link models.OrganizationResponsible
+	if err := utils.DB.Where("organization_id = ? AND user_id = ?", organizationID, employeeID).First(&link).Error; err != nil {
+		http.Error(w, "Сотрудник не является ответственным за организацию", http.StatusNotFound)
+		return models.OrganizationResponsible{}, false
+	}
+	return link, true
+}
+
+// Проверяет, что у организации останется хотя бы один владелец, при ошибке сам отвечает клиенту
+func hasAnotherOwner(w http.ResponseWriter, link models.OrganizationResponsible) bool {
+	var ownersCount int64
+	if err := utils.DB.Model(&models.OrganizationResponsible{}).
+		Where("organization_id = ? AND role = ? AND id <> ?", link.OrganizationID, models.OWNER, link.ID).
+		Count(&ownersCount).Error; err != nil {
+		http.Error(w, "Ошибка подсчета владельцев", http.StatusInternalServerError)
+		return false
+	}
+	if ownersCount == 0 {
+		http.Error(w, "Нельзя удалить или понизить последнего владельца организации", http.StatusConflict)
+		return false
+	}
+	return true
+}
+
+// Ищет организацию по organizationId из URL, при ошибке сам отвечает клиенту
+func findOrganization(w http.ResponseWriter, r *http.Request) (models.Organization, bool) {
+	organizationID, err := strconv.Atoi(mux.Vars(r)["organizationId"])
+	if err != nil {
+		http.Error(w, "Неверный ID организации", http.StatusBadRequest)
+		return models.Organization{}, false
+	}
+
+	organization := models.Organization{ID: uint(organizationID)}
+	if _, err := validators.CheckOrganizationsExist(organization); err != nil {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return models.Organization{}, false
+	}
+	if err := utils.DB.First(&organization, organizationID).Error; err != nil {
+		http.Error(w, "Организация не найдена", http.StatusNotFound)
+		return models.Organization{}, false
+	}
+	return organization, true
+}
+
+// Переносит переданные поля в организацию
+func applyOrganizationRequest(organization *models.Organization, request OrganizationRequest) {
+	if request.Name != nil {
+		organization.Name = *request.Name
+	}
+	if request.Description != nil {
+		organization.Description = *request.Description
+	}
+	if request.Type != nil {
+		organization.Type = *request.Type
+	}
+	if request.QuorumRule != nil {
+		organization.QuorumRule = *request.QuorumRule
+	}
+	if request.QuorumValue != nil {
+		organization.QuorumValue = *request.QuorumValue
+	}
+	if request.SignOffRequired != nil {
+		organization.SignOffRequired = *request.SignOffRequired
+	}
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"log"
+	"net/http"
+	"strconv"
+	"testAvito/middleware"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+	"time"
+)
+
+// QuestionRequest вопрос по условиям тендера
+type QuestionRequest struct {
+	Question string `json:"question"`
+}
+
+// AnswerRequest ответ организатора на вопрос
+type AnswerRequest struct {
+	Answer string `json:"answer"`
+}
+
+// AskTenderQuestionHandler задает вопрос по опубликованному тендеру.
+// @Summary Вопрос по тендеру
+// @Description Участник задает вопрос по условиям опубликованного тендера. Автор вопроса виден только ответственным за тендер.
+// @Tags Questions
+// @Accept  json
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Param question body QuestionRequest true "Текст вопроса"
+// @Success 200 {object} models.QuestionView "Созданный вопрос"
+// @Failure 400 {string} string "Неверный ID, текст вопроса или тендер не опубликован"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Ответственные за тендер не задают вопросы по нему"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка сохранения вопроса"
+// @Router /tenders/{tenderId}/questions [post]
+func AskTenderQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	tender, ok := findQuestionTender(w, r)
+	if !ok {
+		return
+	}
+	if tender.Status != models.PUBLISHED || !canViewTender(tender, &employee) {
+		http.Error(w, "Вопросы можно задавать только по опубликованному тендеру", http.StatusBadRequest)
+		return
+	}
+	if responsible, _ := validators.CheckOrganizationResponsible(tender.OrganizationID, employee.ID); responsible {
+		http.Error(w, "Ответственные за тендер не задают вопросы по нему", http.StatusForbidden)
+		return
+	}
+
+	var request QuestionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные вопроса", http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckQuestionText(request.Question); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	question := models.TenderQuestion{
+		TenderID: tender.ID,
+		AskerID:  employee.ID,
+		Question: request.Question,
+	}
+	if err := utils.DB.Create(&question).Error; err != nil {
+		log.Println("Ошибка сохранения вопроса:", err)
+		http.Error(w, "Ошибка сохранения вопроса", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, questionView(question, &employee, false))
+}
+
+// GetTenderQuestionsHandler возвращает вопросы и ответы по тендеру.
+// @Summary Вопросы и ответы по тендеру
+// @Description Всем, кто видит тендер, возвращает вопросы с ответами, без автора вопроса. Участник дополнительно видит свои неотвеченные вопросы, ответственные за тендер — все вопросы и их авторов.
+// @Tags Questions
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Success 200 {array} models.QuestionView "Список вопросов"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка загрузки вопросов"
+// @Router /tenders/{tenderId}/questions [get]
+func GetTenderQuestionsHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findQuestionTender(w, r)
+	if !ok {
+		return
+	}
+
+	// Смотреть вопросы можно и без авторизации, если тендер виден всем
+	var viewer *models.Employee
+	if employee, ok := middleware.EmployeeFromContext(r.Context()); ok {
+		viewer = &employee
+	}
+	if !canViewTender(tender, viewer) {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return
+	}
+	organizer := viewer != nil && validators.CheckPermission(tender.OrganizationID, viewer.ID, validators.ActionViewTender) == nil
+
+	query := utils.DB.Where("tender_id = ?", tender.ID)
+	switch {
+	case organizer:
+	case viewer != nil:
+		query = query.Where("answered_at IS NOT NULL OR asker_id = ?", viewer.ID)
+	default:
+		query = query.Where("answered_at IS NOT NULL")
+	}
+	var questions []models.TenderQuestion
+	if err := query.Order("id").Find(&questions).Error; err != nil {
+		http.Error(w, "Ошибка загрузки вопросов", http.StatusInternalServerError)
+		return
+	}
+
+	views := make([]models.QuestionView, 0, len(questions))
+	for _, question := range questions {
+		views = append(views, questionView(question, viewer, organizer))
+	}
+	utils.JSONFormat(w, r, views)
+}
+
+// AnswerTenderQuestionHandler отвечает на вопрос по тендеру.
+// @Summary Ответ на вопрос по тендеру
+// @Description Ответственный за тендер отвечает на вопрос участника, повторный вызов исправляет ответ. Ответ становится виден всем, кто видит тендер.
+// @Tags Questions
+// @Accept  json
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Param questionId path int true "ID вопроса"
+// @Security BearerAuth
+// @Param answer body AnswerRequest true "Текст ответа"
+// @Success 200 {object} models.QuestionView "Вопрос с ответом"
+// @Failure 400 {string} string "Неверный ID, текст ответа или тендер не опубликован"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав отвечать на вопросы"
+// @Failure 404 {string} string "Тендер или вопрос не найдены"
+// @Failure 500 {string} string "Ошибка сохранения ответа"
+// @Router /tenders/{tenderId}/questions/{questionId}/answer [put]
+func AnswerTenderQuestionHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	tender, ok := findQuestionTender(w, r)
+	if !ok {
+		return
+	}
+	if !checkPermission(w, tender.OrganizationID, employee.ID, validators.ActionAnswerQuestion, "У вас нет прав отвечать на вопросы по тендеру") {
+		return
+	}
+	if tender.Status != models.PUBLISHED {
+		http.Error(w, "Отвечать на вопросы можно только по опубликованному тендеру", http.StatusBadRequest)
+		return
+	}
+
+	questionID, err := strconv.Atoi(mux.Vars(r)["questionId"])
+	if err != nil {
+		http.Error(w, "Неверный ID вопроса", http.StatusBadRequest)
+		return
+	}
+	var question models.TenderQuestion
+	if err := utils.DB.Where("id = ? AND tender_id = ?", questionID, tender.ID).First(&question).Error; err != nil {
+		http.Error(w, "Вопрос не найден", http.StatusNotFound)
+		return
+	}
+
+	var request AnswerRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные ответа", http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckQuestionText(request.Answer); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	question.Answer = request.Answer
+	question.AnsweredBy = &employee.ID
+	question.AnsweredAt = &now
+	if err := utils.DB.Save(&question).Error; err != nil {
+		log.Println("Ошибка сохранения ответа:", err)
+		http.Error(w, "Ошибка сохранения ответа", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, questionView(question, &employee, true))
+}
+
+// Находит тендер по tenderId из URL, при ошибке сам отвечает клиенту
+func findQuestionTender(w http.ResponseWriter, r *http.Request) (models.Tender, bool) {
+	tenderID, err := strconv.Atoi(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "Неверный тендер ID", http.StatusBadRequest)
+		return models.Tender{}, false
+	}
+
+	var tender models.Tender
+	if err := utils.DB.First(&tender, tenderID).Error; err != nil {
+		http.Error(w, "Тендер не найден", http.StatusNotFound)
+		return models.Tender{}, false
+	}
+	return tender, true
+}
+
+// Готовит вопрос к выдаче: автор вопроса раскрывается только организаторам
+func questionView(question models.TenderQuestion, viewer *models.Employee, organizer bool) models.QuestionView {
+	view := models.QuestionView{
+		ID:         question.ID,
+		TenderID:   question.TenderID,
+		Question:   question.Question,
+		Answer:     question.Answer,
+		Answered:   question.AnsweredAt != nil,
+		AnsweredAt: question.AnsweredAt,
+		Own:        viewer != nil && viewer.ID == question.AskerID,
+		CreatedAt:  question.CreatedAt,
+	}
+	if organizer {
+		askerID := question.AskerID
+		view.AskerID = &askerID
+	}
+	return view
+}
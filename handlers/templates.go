@@ -0,0 +1,337 @@
+package handlers
+
+import (
+	"encoding/json"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+)
+
+// TemplateRequest название сохраняемого шаблона
+type TemplateRequest struct {
+	Title string `json:"title"`
+}
+
+// SaveTenderTemplateHandler сохраняет настройки тендера как шаблон организации.
+// @Summary Сохранение тендера как шаблона
+// @Description Сохраняет название, описание, тип услуг, бюджет, режимы, параметры аукциона, число победителей и правило кворума, лоты, критерии оценки и приглашения тендера в шаблон его организации. Сроки, статус и история версий в шаблон не попадают.
+// @Tags Templates
+// @Accept  json
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Param template body TemplateRequest true "Название шаблона"
+// @Success 200 {object} models.TenderTemplate "Созданный шаблон"
+// @Failure 400 {string} string "Неверное название шаблона или ID тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав создавать тендеры организации"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка сохранения шаблона"
+// @Router /tenders/{tenderId}/template [post]
+func SaveTenderTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	tender, ok := findTenderForAction(w, r, validators.ActionCreateTender, "У вас нет прав создавать тендеры организации")
+	if !ok {
+		return
+	}
+
+	var request TemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверные данные шаблона", http.StatusBadRequest)
+		return
+	}
+	request.Title = strings.TrimSpace(request.Title)
+	if request.Title == "" {
+		http.Error(w, "Название шаблона не может быть пустым", http.StatusBadRequest)
+		return
+	}
+
+	template, err := templateFromTender(tender)
+	if err != nil {
+		log.Println("Ошибка загрузки настроек тендера:", err)
+		http.Error(w, "Ошибка сохранения шаблона", http.StatusInternalServerError)
+		return
+	}
+	template.Title = request.Title
+	template.CreatorUsername = employee.Username
+
+	if err := utils.DB.Create(&template).Error; err != nil {
+		log.Println("Ошибка сохранения шаблона:", err)
+		http.Error(w, "Ошибка сохранения шаблона", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, template)
+}
+
+// GetTenderTemplatesHandler возвращает шаблоны тендеров организации.
+// @Summary Список шаблонов организации
+// @Description Возвращает шаблоны тендеров организации. Доступно ответственным за организацию.
+// @Tags Templates
+// @Produce  json
+// @Param organizationId query int true "ID организации"
+// @Security BearerAuth
+// @Success 200 {array} models.TenderTemplate "Список шаблонов"
+// @Failure 400 {string} string "Неверный ID организации"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр тендеров организации"
+// @Failure 500 {string} string "Ошибка загрузки шаблонов"
+// @Router /tenders/templates [get]
+func GetTenderTemplatesHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	organizationID, err := strconv.Atoi(r.URL.Query().Get("organizationId"))
+	if err != nil {
+		http.Error(w, "Неверный ID организации", http.StatusBadRequest)
+		return
+	}
+	if !checkPermission(w, uint(organizationID), employee.ID, validators.ActionViewTender, "У вас нет прав просматривать тендеры организации") {
+		return
+	}
+
+	var templates []models.TenderTemplate
+	if err := utils.DB.Where("organization_id = ?", organizationID).Order("id").Find(&templates).Error; err != nil {
+		http.Error(w, "Ошибка загрузки шаблонов", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, templates)
+}
+
+// DeleteTenderTemplateHandler удаляет шаблон тендера.
+// @Summary Удаление шаблона
+// @Description Удаляет шаблон организации. Тендеры, созданные по шаблону, не меняются.
+// @Tags Templates
+// @Produce  json
+// @Param templateId path int true "ID шаблона"
+// @Security BearerAuth
+// @Success 200 {object} models.TenderTemplate "Удаленный шаблон"
+// @Failure 400 {string} string "Неверный ID шаблона"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав создавать тендеры организации"
+// @Failure 404 {string} string "Шаблон не найден"
+// @Failure 500 {string} string "Ошибка удаления шаблона"
+// @Router /tenders/templates/{templateId} [delete]
+func DeleteTenderTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	template, _, ok := findTemplate(w, r)
+	if !ok {
+		return
+	}
+
+	if err := utils.DB.Delete(&template).Error; err != nil {
+		http.Error(w, "Ошибка удаления шаблона", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, template)
+}
+
+// CreateTenderFromTemplateHandler создает тендер по шаблону.
+// @Summary Создание тендера по шаблону
+// @Description Создает новый тендер в статусе CREATED с настройками, лотами, критериями и приглашениями из шаблона. Создателем считается авторизованный пользователь.
+// @Tags Templates
+// @Produce  json
+// @Param templateId path int true "ID шаблона"
+// @Security BearerAuth
+// @Success 200 {object} models.Tender "Созданный тендер"
+// @Failure 400 {string} string "Неверный ID шаблона или категория услуг отсутствует в справочнике"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав создавать тендеры организации"
+// @Failure 404 {string} string "Шаблон не найден"
+// @Failure 500 {string} string "Ошибка создания тендера"
+// @Router /tenders/templates/{templateId}/new [post]
+func CreateTenderFromTemplateHandler(w http.ResponseWriter, r *http.Request) {
+	template, employee, ok := findTemplate(w, r)
+	if !ok {
+		return
+	}
+	if err := validators.CheckServiceType(&template.ServiceType); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tender, err := createTenderFromTemplate(template, employee)
+	if err != nil {
+		log.Println("Ошибка создания тендера по шаблону:", err)
+		http.Error(w, "Ошибка создания тендера", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, tender)
+}
+
+// CloneTenderHandler создает копию тендера.
+// @Summary Копирование тендера
+// @Description Создает новый тендер в статусе CREATED с версией 1 и теми же настройками, лотами, критериями и приглашениями. Копировать можно тендер в любом статусе, в том числе закрытый. Сроки подачи и публикации не копируются.
+// @Tags Templates
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Success 200 {object} models.Tender "Созданный тендер"
+// @Failure 400 {string} string "Неверный ID тендера или категория услуг отсутствует в справочнике"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав создавать тендеры организации"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка создания тендера"
+// @Router /tenders/{tenderId}/clone [post]
+func CloneTenderHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	source, ok := findTenderForAction(w, r, validators.ActionCreateTender, "У вас нет прав создавать тендеры организации")
+	if !ok {
+		return
+	}
+
+	template, err := templateFromTender(source)
+	if err != nil {
+		log.Println("Ошибка загрузки настроек тендера:", err)
+		http.Error(w, "Ошибка создания тендера", http.StatusInternalServerError)
+		return
+	}
+	if err := validators.CheckServiceType(&template.ServiceType); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	tender, err := createTenderFromTemplate(template, employee)
+	if err != nil {
+		log.Println("Ошибка копирования тендера:", err)
+		http.Error(w, "Ошибка создания тендера", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, tender)
+}
+
+// Находит шаблон, с которым сотрудник может работать от имени организации
+func findTemplate(w http.ResponseWriter, r *http.Request) (models.TenderTemplate, models.Employee, bool) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return models.TenderTemplate{}, models.Employee{}, false
+	}
+
+	templateID, err := strconv.Atoi(mux.Vars(r)["templateId"])
+	if err != nil {
+		http.Error(w, "Неверный ID шаблона", http.StatusBadRequest)
+		return models.TenderTemplate{}, models.Employee{}, false
+	}
+	var template models.TenderTemplate
+	if err := utils.DB.First(&template, templateID).Error; err != nil {
+		http.Error(w, "Шаблон не найден", http.StatusNotFound)
+		return models.TenderTemplate{}, models.Employee{}, false
+	}
+	if !checkPermission(w, template.OrganizationID, employee.ID, validators.ActionCreateTender, "У вас нет прав создавать тендеры организации") {
+		return models.TenderTemplate{}, models.Employee{}, false
+	}
+	return template, employee, true
+}
+
+// Собирает настройки тендера вместе с лотами, критериями и приглашениями
+func templateFromTender(tender models.Tender) (models.TenderTemplate, error) {
+	template := models.TenderTemplate{
+		OrganizationID:          tender.OrganizationID,
+		Name:                    tender.Name,
+		Description:             tender.Description,
+		ServiceType:             tender.ServiceType,
+		Budget:                  tender.Budget,
+		Currency:                tender.Currency,
+		Sealed:                  tender.Sealed,
+		Visibility:              tender.Visibility,
+		Type:                    tender.Type,
+		AuctionStep:             tender.AuctionStep,
+		AuctionRoundSeconds:     tender.AuctionRoundSeconds,
+		AuctionExtensionSeconds: tender.AuctionExtensionSeconds,
+		MaxWinners:              tender.MaxWinners,
+		QuorumRule:              tender.QuorumRule,
+		QuorumValue:             tender.QuorumValue,
+	}
+
+	var lots []models.Lot
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Order("id").Find(&lots).Error; err != nil {
+		return models.TenderTemplate{}, err
+	}
+	for _, lot := range lots {
+		template.Lots = append(template.Lots, models.TemplateLot{Name: lot.Name, Description: lot.Description, Budget: lot.Budget})
+	}
+
+	var criteria []models.EvaluationCriterion
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Order("id").Find(&criteria).Error; err != nil {
+		return models.TenderTemplate{}, err
+	}
+	for _, criterion := range criteria {
+		template.Criteria = append(template.Criteria, models.TemplateCriterion{Name: criterion.Name, Description: criterion.Description, Weight: criterion.Weight})
+	}
+
+	var invitations []models.TenderInvitation
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Order("id").Find(&invitations).Error; err != nil {
+		return models.TenderTemplate{}, err
+	}
+	for _, invitation := range invitations {
+		template.Invitations = append(template.Invitations, models.TemplateInvitation{OrganizationID: invitation.OrganizationID, EmployeeID: invitation.EmployeeID})
+	}
+	return template, nil
+}
+
+// Создает новый тендер в статусе CREATED с первой версией по настройкам шаблона
+func createTenderFromTemplate(template models.TenderTemplate, employee models.Employee) (models.Tender, error) {
+	tender := models.Tender{
+		Name:                    template.Name,
+		Description:             template.Description,
+		ServiceType:             template.ServiceType,
+		Status:                  models.CREATED,
+		OrganizationID:          template.OrganizationID,
+		CreatorUsername:         employee.Username,
+		Budget:                  template.Budget,
+		Currency:                template.Currency,
+		Sealed:                  template.Sealed,
+		Visibility:              template.Visibility,
+		Type:                    template.Type,
+		AuctionStep:             template.AuctionStep,
+		AuctionRoundSeconds:     template.AuctionRoundSeconds,
+		AuctionExtensionSeconds: template.AuctionExtensionSeconds,
+		MaxWinners:              template.MaxWinners,
+		QuorumRule:              template.QuorumRule,
+		QuorumValue:             template.QuorumValue,
+		Version:                 1,
+	}
+	if err := inheritQuorumRule(&tender); err != nil {
+		return models.Tender{}, err
+	}
+	// Тендер создается целиком или не создается вовсе
+	err := utils.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&tender).Error; err != nil {
+			return err
+		}
+		if err := saveTenderVersion(tx, tender, &employee.ID); err != nil {
+			return err
+		}
+
+		for _, lot := range template.Lots {
+			if err := tx.Create(&models.Lot{TenderID: tender.ID, Name: lot.Name, Description: lot.Description, Budget: lot.Budget}).Error; err != nil {
+				return err
+			}
+		}
+		for _, criterion := range template.Criteria {
+			if err := tx.Create(&models.EvaluationCriterion{TenderID: tender.ID, Name: criterion.Name, Description: criterion.Description, Weight: criterion.Weight}).Error; err != nil {
+				return err
+			}
+		}
+		for _, invitation := range template.Invitations {
+			if err := tx.Create(&models.TenderInvitation{TenderID: tender.ID, OrganizationID: invitation.OrganizationID, EmployeeID: invitation.EmployeeID}).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return models.Tender{}, err
+	}
+	return tender, nil
+}
@@ -2,31 +2,45 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"github.com/gorilla/mux"
+	"gorm.io/gorm"
 	"log"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
+	"testAvito/middleware"
 	"testAvito/models"
 	"testAvito/utils"
 	"testAvito/validators"
+	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 // Создание тендера
 // CreateTenderHandler создает новый тендер.
 // @Summary Создание нового тендера
-// @Description Создает новый тендер, декодируя данные из тела запроса и сохраняя их в базе данных.
+// @Description Создает новый тендер, декодируя данные из тела запроса и сохраняя их в базе данных. Если правило кворума QuorumRule не задано, берется правило организации.
 // @Tags Tenders
 // @Accept  json
 // @Produce  json
+// @Security BearerAuth
 // @Param tender body models.Tender true "Данные для создания тендера"
 // @Success 200 {object} models.Tender "Успешно созданный тендер"
 // @Failure 400 {string} string "Неверные данные для создания тендера"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 500 {string} string "Ошибка сохранения тендера в базе данных"
 // @Router /tenders/new [post]
 func CreateTenderHandler(w http.ResponseWriter, r *http.Request) {
 	var tender models.Tender
 
 	log.Println("Получен запрос на создание тендера")
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
 
 	// Декодируем тело запроса
 	if err := json.NewDecoder(r.Body).Decode(&tender); err != nil {
@@ -36,11 +50,90 @@ func CreateTenderHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	log.Println("Декодирование JSON прошло успешно")
+	// Создателем тендера всегда считается авторизованный пользователь
+	tender.CreatorUsername = employee.Username
+	// Итог, причину отмены и состояние аукциона ведет только сервер
+	tender.Outcome = ""
+	tender.CancellationReason = ""
+	tender.AuctionRound = 0
+	tender.AuctionRoundBids = 0
+	tender.AuctionRoundEndsAt = nil
+	// Согласование начинается только отдельным запросом
+	tender.ApprovalStatus = ""
+	tender.ApprovalRound = 0
+	tender.ApprovalsRequired = 0
+	tender.ApprovalSubmittedBy = nil
 	if err := validators.ValidateCreateTender(w, &tender); err != nil {
 		return
 	}
-	// Создаем тендер в базе данных
-	if err := utils.DB.Create(&tender).Error; err != nil {
+	if tenderFinished(tender) {
+		http.Error(w, "Тендер нельзя создать сразу завершенным, используйте статус CREATED или PUBLISHED", http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckSubmissionDeadline(tender.SubmissionDeadline); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validators.ValidateTenderBudget(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckSealed(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validators.ValidateAuction(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckVisibility(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckServiceType(&tender.ServiceType); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := inheritQuorumRule(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckMaxWinners(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if tender.Status == models.PUBLISHED {
+		if err := checkTenderSignedOff(tender); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if tender.Status == models.PUBLISHED && tender.Type == models.AUCTION {
+		startAuction(&tender)
+	}
+	if tender.PublishAt != nil {
+		if tender.Status != models.CREATED {
+			http.Error(w, "Запланировать публикацию можно только для тендера в статусе CREATED", http.StatusBadRequest)
+			return
+		}
+		if err := validators.CheckPublishAt(*tender.PublishAt, tender.SubmissionDeadline); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := checkTenderSignedOff(tender); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	// Создаем тендер в базе данных вместе с первой версией
+	err := utils.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&tender).Error; err != nil {
+			return err
+		}
+		// Сохраняем для контроля версий
+		return saveTenderVersion(tx, tender, &employee.ID)
+	})
+	if err != nil {
 		log.Println("Ошибка создания тендера в базе данных:", err)
 		http.Error(w, "Ошибка создания тендера", http.StatusInternalServerError)
 		return
@@ -48,26 +141,28 @@ func CreateTenderHandler(w http.ResponseWriter, r *http.Request) {
 
 	log.Println("Тендер успешно создан в базе данных")
 
-	// Сохраняем для контроля версий
-	saveTenderVersion(tender)
-
 	// Форматируем JSON с отступами для лучшего чтения
 	utils.JSONFormat(w, r, tender)
 }
 
 // SetStatusTenderHandler изменяет статус тендера по его ID.
 // @Summary Изменение статуса тендера
-// @Description Позволяет изменить статус тендера на "publish" или "close", если пользователь имеет права доступа.
+// @Description Позволяет опубликовать тендер ("publish"), закрыть его ("close") или отменить ("cancel") с обязательной причиной reason, если пользователь имеет права доступа. Отмена переводит тендер из CREATED или PUBLISHED в CANCELLED и отменяет все незавершенные предложения.
 // @Tags Tenders
 // @Accept  json
 // @Produce  json
 // @Param tenderId path int true "ID тендера"
-// @Param username query string true "Имя пользователя, изменяющего статус"
-// @Param status query string true "Новый статус тендера ('publish' или 'close')"
+// @Security BearerAuth
+// @Param status query string true "Новый статус тендера ('publish', 'close' или 'cancel')"
+// @Param reason query string false "Причина отмены, обязательна для 'cancel'"
+// @Param If-Match header string false "ETag версии, которую видел клиент"
 // @Success 200 {object} models.Tender "Успешно обновленный тендер"
-// @Failure 400 {string} string "Неверный ID тендера или неправильный статус"
+// @Header 200 {string} ETag "Версия объекта после изменения"
+// @Failure 400 {string} string "Неверный ID тендера, неправильный статус или тендер не прошел согласование"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Нет прав для изменения статуса"
 // @Failure 404 {string} string "Тендер или пользователь не найдены"
+// @Failure 412 {string} string "Версия устарела, объект изменен другим пользователем"
 // @Failure 500 {string} string "Ошибка обновления тендера"
 // @Router /tenders/{tenderId}/status [put]
 func SetStatusTenderHandler(w http.ResponseWriter, r *http.Request) {
@@ -78,28 +173,34 @@ func SetStatusTenderHandler(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Неверный тендер ID", http.StatusBadRequest)
 		return
 	}
-	username := r.URL.Query().Get("username")
 	status := r.URL.Query().Get("status")
-	var employee models.Employee
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	employee, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
 
-	var tender models.Tender
-	// Далее среди тендеров ищу тот же тендер что и с этим же айдишником
-	if err := utils.DB.First(&tender, tenderId).Error; err != nil {
-		http.Error(w, "Тендер не найден", http.StatusNotFound)
+	// Далее среди тендеров ищу тот же тендер что и с этим же айдишником, он блокируется до конца транзакции
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, err := lockTender(tx, uint(tenderId))
+	if err != nil {
+		writeLockError(w, err)
 		return
 	}
-	if tender.Status == models.CLOSED {
-		http.Error(w, "Тендер был закрыт, изменения невозможны.", http.StatusBadRequest)
+	if tenderFinished(tender) {
+		http.Error(w, "Тендер был закрыт или отменен, изменения невозможны.", http.StatusBadRequest)
 		return
 	}
 
-	var organizationResponsible models.OrganizationResponsible
-	if err := utils.DB.Where("organization_id = ? AND user_id = ?", tender.OrganizationID, employee.ID).First(&organizationResponsible).Error; err != nil {
-		http.Error(w, "У вас нет прав изменять статус этого тендера", http.StatusForbidden)
+	// Публикация и закрытие (отмена) требуют отдельных прав
+	action := validators.ActionPublishTender
+	if status == "close" || status == "cancel" {
+		action = validators.ActionCloseTender
+	}
+	if !checkPermission(w, tender.OrganizationID, employee.ID, action, "У вас нет прав изменять статус этого тендера") {
+		return
+	}
+	if !checkIfMatch(w, r, tender.Version) {
 		return
 	}
 
@@ -110,85 +211,173 @@ func SetStatusTenderHandler(w http.ResponseWriter, r *http.Request) {
 			http.Error(w, "Тендер должен быть в статусе CREATED", http.StatusBadRequest)
 			return
 		}
-		tender.Status = models.PUBLISHED
-		tender.Version++
+		if err := checkTenderSignedOff(tender); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		publishTender(&tender)
 		log.Println("Тендер был опубликован")
 	case "close":
 		if tender.Status != models.PUBLISHED {
 			http.Error(w, "Тендер должен быть в статусе PUBLISHED", http.StatusBadRequest)
 			return
 		}
-		tender.Status = models.CLOSED
-		var bids []models.Bid
-		if err := utils.DB.Where("tender_id = ?", tender.ID).Find(&bids).Error; err != nil {
-			http.Error(w, "Ошибка c поиском предложения по тендер айди", http.StatusInternalServerError)
+		if err := closeTender(tx, &tender); err != nil {
+			log.Println("Ошибка закрытия тендера:", err)
+			http.Error(w, "Ошибка закрытия предложений тендера", http.StatusInternalServerError)
 			return
-
-		}
-
-		// if len(bids) == 0 {
-		// 	http.Error(w, "Предложения не найдены для этого тендера.", http.StatusNotFound)
-		// 	return
-		// }
-		for _, bid := range bids {
-			if bid.Status != models.CANCELED && bid.Status != models.PUBLISHEDBid {
-				bid.Status = models.CANCELED
-				bid.Version++
-				saveBidsVersion(bid)
-				if err := utils.DB.Save(&bid).Error; err != nil {
-					http.Error(w, "Ошибка закрытия предложения", http.StatusInternalServerError)
-					return
-				}
-			}
 		}
-		tender.Version++
 		log.Println("Тендер закрыт")
+	case "cancel":
+		reason := r.URL.Query().Get("reason")
+		if err := validators.CheckCancellationReason(reason); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := cancelTender(tx, &tender, reason); err != nil {
+			log.Println("Ошибка отмены тендера:", err)
+			http.Error(w, "Ошибка отмены предложений тендера", http.StatusInternalServerError)
+			return
+		}
+		log.Println("Тендер отменен")
 	default:
-		http.Error(w, "Неправильное действие. Используй 'publish' или 'close'.", http.StatusBadRequest)
+		http.Error(w, "Неправильное действие. Используй 'publish', 'close' или 'cancel'.", http.StatusBadRequest)
 		return
 	}
 
 	// Сохранение в базу данных
-	if err := utils.DB.Save(&tender).Error; err != nil {
+	if err := tx.Save(&tender).Error; err != nil {
+		http.Error(w, "Ошибка обновления статуса тендера", http.StatusInternalServerError)
+		return
+	}
+	if err := saveTenderVersion(tx, tender, &employee.ID); err != nil {
+		http.Error(w, "Ошибка сохранения версии тендера", http.StatusInternalServerError)
+		return
+	}
+	if err := tx.Commit().Error; err != nil {
 		http.Error(w, "Ошибка обновления статуса тендера", http.StatusInternalServerError)
 		return
 	}
-	saveTenderVersion(tender)
 
 	// В красивом формате
 	utils.JSONFormat(w, r, tender)
 }
 
-// Тендер всех пользователей с возможностью фильтрации по типу сервиса
-// TenderShowHandler возвращает список всех тендеров с возможностью фильтрации по типу услуг.
+// Тендер всех пользователей с возможностью фильтрации
+// TenderShowHandler возвращает список тендеров с фильтрами по статусу, организации, дате создания и типам услуг.
 // @Summary Получение списка тендеров
-// @Description Возвращает список всех тендеров с возможностью фильтрации по типу услуг.
+// @Description Без фильтра status возвращает только опубликованные тендеры, а ответственным — еще и черновики (CREATED) их организаций. Черновики чужих организаций не видны даже при явном фильтре. Тендеры с видимостью INVITE_ONLY видны только ответственным за них и приглашенным. Фильтры status и serviceType принимают несколько значений через запятую или повтором параметра, категория услуг включает подкатегории.
 // @Tags Tenders
 // @Accept  json
 // @Produce  json
-// @Param serviceType query string false "Тип услуг для фильтрации тендеров"
+// @Param status query []string false "Статусы тендеров (PUBLISHED, CREATED, CLOSED, CANCELLED)" collectionFormat(csv)
+// @Param organizationId query int false "ID организации"
+// @Param createdFrom query string false "Созданы не раньше (RFC3339 или YYYY-MM-DD)"
+// @Param createdTo query string false "Созданы не позже (RFC3339 или YYYY-MM-DD включительно)"
+// @Param serviceType query []string false "Коды категорий услуг для фильтрации тендеров" collectionFormat(csv)
 // @Success 200 {array} models.Tender "Список тендеров"
+// @Failure 400 {string} string "Неверный фильтр"
 // @Failure 500 {string} string "Ошибка загрузки тендеров"
 // @Router /tenders [get]
 func TenderShowHandler(w http.ResponseWriter, r *http.Request) {
 	var tenders []models.Tender
-	serviceType := r.URL.Query().Get("serviceType")
+	params := r.URL.Query()
 
-	if serviceType != "" {
-		log.Printf("Фильтрация по типу услуг: %s", serviceType)
-		if err := utils.DB.Where("service_type = ?", serviceType).Find(&tenders).Error; err != nil {
-			http.Error(w, "Ошибка поимка тендера.", http.StatusInternalServerError)
+	// Список доступен и без авторизации, тогда видны только публичные тендеры
+	var viewer *models.Employee
+	if employee, ok := middleware.EmployeeFromContext(r.Context()); ok {
+		viewer = &employee
+	}
+	query := visibleTenders(utils.DB.Model(&models.Tender{}), viewer)
+
+	// Черновики видны только ответственным за организацию тендера
+	var orgIDs []uint
+	if viewer != nil {
+		orgIDs = employeeOrganizationIDs(viewer.ID)
+	}
+	statuses := queryList(params, "status")
+	if len(statuses) == 0 {
+		query = query.Where("status = ? OR (status = ? AND organization_id IN ?)", models.PUBLISHED, models.CREATED, orgIDs)
+	} else {
+		for _, status := range statuses {
+			if err := validators.CheckCorrectStatusTender(models.TenderStatus(status)); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		query = query.Where("status IN ? AND (status <> ? OR organization_id IN ?)", statuses, models.CREATED, orgIDs)
+	}
+
+	if organizationID := params.Get("organizationId"); organizationID != "" {
+		id, err := strconv.Atoi(organizationID)
+		if err != nil {
+			http.Error(w, "Неверный ID организации", http.StatusBadRequest)
 			return
 		}
-	} else {
-		if err := utils.DB.Find(&tenders).Error; err != nil {
-			http.Error(w, "Ошибка поимка тендера.", http.StatusInternalServerError)
+		query = query.Where("organization_id = ?", id)
+	}
+
+	if createdFrom := params.Get("createdFrom"); createdFrom != "" {
+		from, _, err := parseDateParam(createdFrom)
+		if err != nil {
+			http.Error(w, "Неверный формат createdFrom, ожидается RFC3339 или YYYY-MM-DD", http.StatusBadRequest)
+			return
+		}
+		query = query.Where("created_at >= ?", from)
+	}
+	if createdTo := params.Get("createdTo"); createdTo != "" {
+		to, dateOnly, err := parseDateParam(createdTo)
+		if err != nil {
+			http.Error(w, "Неверный формат createdTo, ожидается RFC3339 или YYYY-MM-DD", http.StatusBadRequest)
 			return
 		}
+		// Дата без времени включает весь день
+		if dateOnly {
+			query = query.Where("created_at < ?", to.AddDate(0, 0, 1))
+		} else {
+			query = query.Where("created_at <= ?", to)
+		}
+	}
+
+	if serviceTypes := queryList(params, "serviceType"); len(serviceTypes) > 0 {
+		log.Printf("Фильтрация по типам услуг: %v", serviceTypes)
+		for i := range serviceTypes {
+			if err := validators.CheckServiceType(&serviceTypes[i]); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		query = tendersInCategory(query, serviceTypes...)
+	}
+	if err := query.Order("created_at DESC").Find(&tenders).Error; err != nil {
+		http.Error(w, "Ошибка поимка тендера.", http.StatusInternalServerError)
+		return
 	}
 	utils.JSONFormat(w, r, tenders)
 }
 
+// Значения параметра запроса, переданные повтором параметра или через запятую, без пустых
+func queryList(params url.Values, name string) []string {
+	var values []string
+	for _, param := range params[name] {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// Разбирает дату фильтра в формате RFC3339 или YYYY-MM-DD, второй результат — передана ли дата без времени
+func parseDateParam(value string) (time.Time, bool, error) {
+	if date, err := time.Parse(time.DateOnly, value); err == nil {
+		return date, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
+
 // GetStatusTenderHandler возвращает статус тендера по его ID.
 // @Summary Получение статуса тендера
 // @Description Возвращает статус тендера, если пользователь имеет права на просмотр статуса.
@@ -196,9 +385,10 @@ func TenderShowHandler(w http.ResponseWriter, r *http.Request) {
 // @Accept  json
 // @Produce  plain
 // @Param tenderId path int true "ID тендера"
-// @Param username query string true "Имя пользователя, запрашивающего статус тендера"
+// @Security BearerAuth
 // @Success 200 {string} string "Статус тендера"
-// @Failure 400 {string} string "Неправильный ID тендера или никнейм не введен"
+// @Failure 400 {string} string "Неправильный ID тендера"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Пользователь не является ответственным за тендер"
 // @Failure 404 {string} string "Тендер или пользователь не найдены"
 // @Failure 500 {string} string "Ошибка сервера"
@@ -207,16 +397,9 @@ func GetStatusTenderHandler(w http.ResponseWriter, r *http.Request) {
 	// Получаем параметры из URL
 	params := mux.Vars(r)
 
-	// Получаем имя пользователя из query
-	username := r.URL.Query().Get("username")
-	if username == "" {
-		http.Error(w, "Никнейм пользователя, кто хочет проверить статус тендера не введено", http.StatusBadRequest)
-	}
-	var employee models.Employee
-
-	// Проверяем, что пользователь существует
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	// Получаем авторизованного пользователя
+	employee, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
 
@@ -235,9 +418,7 @@ func GetStatusTenderHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Проверяем, является ли пользователь ответственным за организацию
-	var orgResponsible models.OrganizationResponsible
-	if err = utils.DB.Where("organization_id = ? AND user_id = ?", tender.OrganizationID, employee.ID).First(&orgResponsible).Error; err != nil {
-		http.Error(w, "Пользователь не является ответственным за тендер", http.StatusForbidden)
+	if !checkPermission(w, tender.OrganizationID, employee.ID, validators.ActionViewTender, "Пользователь не является ответственным за тендер") {
 		return
 	}
 	w.WriteHeader(http.StatusOK)
@@ -248,18 +429,22 @@ func GetStatusTenderHandler(w http.ResponseWriter, r *http.Request) {
 // Показать тендер определенного пользователя
 // ShowTenderUserHandler возвращает список тендеров, созданных пользователем.
 // @Summary Получение тендеров пользователя
-// @Description Возвращает список всех тендеров, созданных пользователем по его имени.
+// @Description Возвращает список всех тендеров, созданных авторизованным пользователем.
 // @Tags Tenders
 // @Accept  json
 // @Produce  json
-// @Param username query string true "Имя пользователя, создавшего тендеры"
+// @Security BearerAuth
 // @Success 200 {array} models.Tender "Список тендеров пользователя"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 500 {string} string "Ошибка поиска тендеров"
 // @Router /tenders/my [get]
 func ShowTenderUserHandler(w http.ResponseWriter, r *http.Request) {
-	username := r.URL.Query().Get("username")
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
 	var tenders []models.Tender
-	if err := utils.DB.Where("creator_username = ?", username).Find(&tenders).Error; err != nil {
+	if err := utils.DB.Where("creator_username = ?", employee.Username).Find(&tenders).Error; err != nil {
 		http.Error(w, "Ошибка поиска тендера.", http.StatusInternalServerError)
 		return
 	}
@@ -270,28 +455,30 @@ func ShowTenderUserHandler(w http.ResponseWriter, r *http.Request) {
 // Изменить тендер (поиск его по id)
 // EditTenderHandler редактирует тендер по его ID.
 // @Summary Редактирование тендера
-// @Description Обновляет данные тендера (имя, описание, тип услуг) по его ID, если пользователь имеет права.
+// @Description Обновляет данные тендера (имя, описание, тип услуг, срок подачи предложений submissionDeadline, максимальную цену budget, валюту currency, режим закрытых предложений sealed, видимость visibility, тип type и параметры аукциона auctionStep, auctionRoundSeconds, auctionExtensionSeconds, число победителей maxWinners, правило кворума quorumRule и quorumValue до публикации) по его ID, если пользователь имеет права. Изменение условий опубликованного тендера записывается как официальное изменение с пояснением changeNote, а поданные предложения помечаются как требующие подтверждения.
 // @Tags Tenders
 // @Accept  json
 // @Produce  json
 // @Param tenderId path int true "ID тендера"
-// @Param username query string true "Имя пользователя, инициирующего изменение"
-// @Param tender body object true "Данные для обновления тендера (имя, описание, тип услуг)"
+// @Security BearerAuth
+// @Param tender body object true "Данные для обновления тендера (имя, описание, тип услуг, submissionDeadline, budget, currency, sealed, visibility, type, auctionStep, auctionRoundSeconds, auctionExtensionSeconds; для опубликованного тендера обязателен changeNote)"
+// @Param If-Match header string false "ETag версии, которую видел клиент"
 // @Success 200 {object} models.Tender "Обновленный тендер"
+// @Header 200 {string} ETag "Версия объекта после изменения"
 // @Failure 400 {string} string "Неверные данные или ID тендера"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Нет прав на редактирование тендера"
 // @Failure 404 {string} string "Тендер или пользователь не найдены"
+// @Failure 412 {string} string "Версия устарела, объект изменен другим пользователем"
 // @Failure 500 {string} string "Ошибка обновления тендера"
 // @Router /tenders/{tenderId}/edit [patch]
 func EditTenderHandler(w http.ResponseWriter, r *http.Request) {
 	// Получаем параметр tenderId из URL
 	params := mux.Vars(r)
 	tenderIDStr := params["tenderId"]
-	username := r.URL.Query().Get("username")
 
-	var employee models.Employee
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	employee, ok := currentEmployee(w, r)
+	if !ok {
 		return
 	}
 	// Преобразуем tenderId в число
@@ -302,36 +489,151 @@ func EditTenderHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Найдем тендер по tenderId
-	var tender models.Tender
-	if err := utils.DB.First(&tender, tenderID).Error; err != nil {
-		log.Println("Тендер не найден:", err)
-		http.Error(w, "Тендер не найден", http.StatusNotFound)
+	// Найдем тендер по tenderId и заблокируем его до конца транзакции
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, err := lockTender(tx, uint(tenderID))
+	if err != nil {
+		writeLockError(w, err)
 		return
 	}
 
-	var organizationResponsible models.OrganizationResponsible
-	if err := utils.DB.Where("organization_id = ? AND user_id = ?", tender.OrganizationID, employee.ID).First(&organizationResponsible).Error; err != nil {
-		http.Error(w, "У вас нет прав изменять тендер.", http.StatusForbidden)
+	if !checkPermission(w, tender.OrganizationID, employee.ID, validators.ActionEditTender, "У вас нет прав изменять тендер.") {
+		return
+	}
+	if !checkIfMatch(w, r, tender.Version) {
+		return
+	}
+	if tenderFinished(tender) {
+		http.Error(w, "Тендер был закрыт или отменен, изменения невозможны.", http.StatusBadRequest)
 		return
 	}
 
+	// Запоминаем условия до изменения, чтобы оформить изменение опубликованного тендера
+	before := tender
+
 	// Декодируем обновлённые данные тендера из тела запроса
 	var updatedTender map[string]interface{}
-	if err := json.NewDecoder(r.Body).Decode(&updatedTender); err != nil {
+	decoder := json.NewDecoder(r.Body)
+	// Числа читаем как json.Number, чтобы не терять точность денежных сумм
+	decoder.UseNumber()
+	if err := decoder.Decode(&updatedTender); err != nil {
 		log.Println("Ошибка при декодировании JSON:", err)
 		http.Error(w, "Неправильные введенные данные", http.StatusBadRequest)
 		return
 	}
 	// Обновляем поля тендера только те которые были переданы
 	if name, ok := updatedTender["name"]; ok {
-		tender.Name = name.(string)
+		value, ok := name.(string)
+		if !ok {
+			http.Error(w, "Неверный формат name, ожидается строка", http.StatusBadRequest)
+			return
+		}
+		tender.Name = value
 	}
 	if description, ok := updatedTender["description"]; ok {
-		tender.Description = description.(string)
+		value, ok := description.(string)
+		if !ok {
+			http.Error(w, "Неверный формат description, ожидается строка", http.StatusBadRequest)
+			return
+		}
+		tender.Description = value
 	}
 	if serviceType, ok := updatedTender["serviceType"]; ok {
-		tender.ServiceType = serviceType.(string)
+		value, ok := serviceType.(string)
+		if !ok {
+			http.Error(w, "Неверный формат serviceType, ожидается строка", http.StatusBadRequest)
+			return
+		}
+		tender.ServiceType = value
+		if err := validators.CheckServiceType(&tender.ServiceType); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if deadline, ok := updatedTender["submissionDeadline"]; ok {
+		// null снимает срок подачи предложений
+		parsed, err := parseOptionalTime(deadline)
+		if err != nil {
+			http.Error(w, "Неверный формат submissionDeadline, ожидается RFC3339", http.StatusBadRequest)
+			return
+		}
+		if err := validators.CheckSubmissionDeadline(parsed); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if tender.PublishAt != nil {
+			if err := validators.CheckPublishAt(*tender.PublishAt, parsed); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		tender.SubmissionDeadline = parsed
+	}
+	if budget, ok := updatedTender["budget"]; ok {
+		// null снимает максимальную цену тендера
+		parsed, err := parseOptionalDecimal(budget)
+		if err != nil {
+			http.Error(w, "Неверный формат budget, ожидается число", http.StatusBadRequest)
+			return
+		}
+		tender.Budget = parsed
+	}
+	if currency, ok := updatedTender["currency"].(string); ok {
+		tender.Currency = currency
+	}
+	if err := validators.ValidateTenderBudget(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if sealed, ok := updatedTender["sealed"].(bool); ok && sealed != tender.Sealed {
+		// Режим закрытых предложений нельзя менять после публикации
+		if tender.Status != models.CREATED {
+			http.Error(w, "Режим закрытых предложений можно менять только в статусе CREATED", http.StatusBadRequest)
+			return
+		}
+		tender.Sealed = sealed
+	}
+	if err := validators.CheckSealed(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := applyAuctionSettings(&tender, updatedTender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := applyQuorumSettings(&tender, updatedTender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if maxWinners, ok := updatedTender["maxWinners"]; ok {
+		if tender.Status != models.CREATED {
+			http.Error(w, "Число победителей можно менять только в статусе CREATED", http.StatusBadRequest)
+			return
+		}
+		winners, err := parseJSONInt(maxWinners)
+		if err != nil {
+			http.Error(w, "Неверный формат maxWinners, ожидается целое число", http.StatusBadRequest)
+			return
+		}
+		tender.MaxWinners = winners
+	}
+	// Тип тендера тоже влияет на допустимое число победителей
+	if err := validators.CheckMaxWinners(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if visibility, ok := updatedTender["visibility"].(string); ok && models.TenderVisibility(visibility) != tender.Visibility {
+		// Открыть тендер можно всегда, а скрыть от уже видевших его участников — только до публикации
+		if models.TenderVisibility(visibility) == models.INVITEONLYVisibility && tender.Status != models.CREATED {
+			http.Error(w, "Сделать тендер доступным только по приглашениям можно только в статусе CREATED", http.StatusBadRequest)
+			return
+		}
+		tender.Visibility = models.TenderVisibility(visibility)
+		if err := validators.CheckVisibility(&tender); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 	}
 	//if status, ok := updatedTender["status"]; ok {
 	//	tender.Status = models.TenderStatus(status.(string))
@@ -342,17 +644,47 @@ func EditTenderHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Изменение условий опубликованного тендера оформляется официально с пояснением
+	var changed []string
+	note, _ := updatedTender["changeNote"].(string)
+	if tender.Status == models.PUBLISHED {
+		changed = changedTenderFields(before, tender)
+		if len(changed) > 0 {
+			if err := validators.CheckChangeNote(note); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+
+	// Измененный черновик нужно согласовать заново
+	resetTenderApproval(&tender)
 	// Увеличиваем версию тендера с каждым изменением
 	tender.Version++
 
 	// Сохраняем изменения в базе данных
-	if err := utils.DB.Save(&tender).Error; err != nil {
+	if err := tx.Save(&tender).Error; err != nil {
 		log.Println("Ошибка при сохранении тендера:", err)
 		http.Error(w, "Ошибка обновления тендера", http.StatusInternalServerError)
 		return
 	}
 	// Сохраняем для контроля версий
-	saveTenderVersion(tender)
+	if err := saveTenderVersion(tx, tender, &employee.ID); err != nil {
+		http.Error(w, "Ошибка сохранения версии тендера", http.StatusInternalServerError)
+		return
+	}
+
+	if len(changed) > 0 {
+		if err := recordAmendment(tx, tender, employee.ID, note, changed); err != nil {
+			log.Println("Ошибка сохранения изменения условий:", err)
+			http.Error(w, "Ошибка сохранения изменения условий тендера", http.StatusInternalServerError)
+			return
+		}
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка обновления тендера", http.StatusInternalServerError)
+		return
+	}
 
 	// В красивом формате
 	utils.JSONFormat(w, r, tender)
@@ -361,17 +693,21 @@ func EditTenderHandler(w http.ResponseWriter, r *http.Request) {
 // Откат тендера к определённой версии
 // RollbackTenderHandler откатывает тендер к указанной версии.
 // @Summary Откат тендера к версии
-// @Description Откатывает тендер к указанной версии на основании прав пользователя и статуса тендера. Откат невозможен, если тендер уже закрыт.
+// @Description Откатывает тендер к указанной версии на основании прав пользователя и статуса тендера. Откат невозможен, если тендер уже закрыт. Статус тендера откатом не меняется. Восстановленные условия проверяются так же, как при редактировании: срок подачи предложений должен быть в будущем, у закрытого (sealed) тендера он обязателен, у аукциона не задается.
 // @Tags Tenders
 // @Accept  json
 // @Produce  json
 // @Param tenderId path int true "ID тендера"
 // @Param version path int true "Версия тендера, к которой необходимо откатиться"
-// @Param username query string true "Имя пользователя, инициирующего откат"
+// @Security BearerAuth
+// @Param If-Match header string false "ETag версии, которую видел клиент"
 // @Success 200 {object} models.Tender "Откатанный тендер"
-// @Failure 400 {string} string "Неправильный ID тендера или версия"
+// @Header 200 {string} ETag "Версия объекта после изменения"
+// @Failure 400 {string} string "Неправильный ID тендера или версия, неверные восстановленные условия"
+// @Failure 401 {string} string "Необходима авторизация"
 // @Failure 403 {string} string "Нет прав на откат тендера"
 // @Failure 404 {string} string "Тендер или версия не найдены"
+// @Failure 412 {string} string "Версия устарела, объект изменен другим пользователем"
 // @Failure 500 {string} string "Ошибка сохранения откатанного тендера"
 // @Router /tenders/{tenderId}/rollback/{version} [put]
 func RollbackTenderHandler(w http.ResponseWriter, r *http.Request) {
@@ -402,64 +738,607 @@ func RollbackTenderHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Ищем текущий тендер по его ID
-	var tender models.Tender
-	if err := utils.DB.First(&tender, tenderID).Error; err != nil {
-		log.Println("Тендер не найден:", err)
-		http.Error(w, "Тендер не найден", http.StatusNotFound)
+	// Ищем текущий тендер по его ID и блокируем его до конца транзакции
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, err := lockTender(tx, uint(tenderID))
+	if err != nil {
+		writeLockError(w, err)
 		return
 	}
-	if tender.Status == models.CLOSED {
-		http.Error(w, "Тендер закрыт, дальнейшее использование его невозможно", http.StatusBadRequest)
+	if tenderFinished(tender) {
+		http.Error(w, "Тендер закрыт или отменен, дальнейшее использование его невозможно", http.StatusBadRequest)
 		return
 	}
 
-	username := r.URL.Query().Get("username")
-	var employee models.Employee
-	if err := utils.DB.Where("username = ?", username).First(&employee).Error; err != nil {
-		http.Error(w, "Пользователь не найден", http.StatusNotFound)
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	if !checkPermission(w, tender.OrganizationID, employee.ID, validators.ActionRollbackTender, "Вы не можете обращаться к прошлым версиям тендера, у вас нет прав.") {
 		return
 	}
-	var organizationResponsible models.OrganizationResponsible
-	if err := utils.DB.Where("organization_id = ? AND user_id = ?", tender.OrganizationID, employee.ID).First(&organizationResponsible).Error; err != nil {
-		http.Error(w, "Вы не можете обращаться к прошлым версиям тендера, у вас нет прав.", http.StatusForbidden)
+	if !checkIfMatch(w, r, tender.Version) {
 		return
 	}
 
 	// Обновляем текущий тендер данными из выбранной версии
+	before := tender
 	tender.Name = tenderVersion.Name
 	tender.Description = tenderVersion.Description
 	tender.ServiceType = tenderVersion.ServiceType
-	tender.Status = tenderVersion.Status
+	// Статус откатом не меняется: публикация и снятие с публикации идут только через status,
+	// где тендер проходит согласование, запуск аукциона и отмену поданных предложений
+	tender.SubmissionDeadline = tenderVersion.SubmissionDeadline
+	tender.Budget = tenderVersion.Budget
+	tender.Currency = tenderVersion.Currency
+	// Правило кворума меняется только до публикации, поэтому и откатывается только до нее
+	if before.Status == models.CREATED && tenderVersion.QuorumRule != "" {
+		tender.QuorumRule = tenderVersion.QuorumRule
+		tender.QuorumValue = tenderVersion.QuorumValue
+	}
 
 	// Используем ту же версию, к которой откатились
 	//tender.Version = tenderVersion.Version - по тз не понял как изменять версию
 
+	// Восстановленные условия проверяются так же, как при редактировании
+	if tender.ServiceType != before.ServiceType {
+		// Версии до появления справочника хранят категорию произвольным текстом
+		if err := validators.CheckServiceType(&tender.ServiceType); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if !sameTime(before.SubmissionDeadline, tender.SubmissionDeadline) {
+		if err := validators.CheckSubmissionDeadline(tender.SubmissionDeadline); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if tender.PublishAt != nil {
+			if err := validators.CheckPublishAt(*tender.PublishAt, tender.SubmissionDeadline); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	}
+	if err := validators.ValidateTenderBudget(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckSealed(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := validators.ValidateAuction(&tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Откат черновика требует согласовать его заново
+	resetTenderApproval(&tender)
+
 	tender.Version++
 
 	// Сохраняем откатанный тендер с обновлёнными данными, сохраняя его ID
-	if err := utils.DB.Save(&tender).Error; err != nil {
+	if err := tx.Save(&tender).Error; err != nil {
 		log.Println("Ошибка при сохранении откатанного тендера:", err)
 		http.Error(w, "Ошибка при сохранении откатанного тендера", http.StatusInternalServerError)
 		return
 	}
 
-	saveTenderVersion(tender)
+	if err := saveTenderVersion(tx, tender, &employee.ID); err != nil {
+		http.Error(w, "Ошибка сохранения версии тендера", http.StatusInternalServerError)
+		return
+	}
+
+	// Откат опубликованного тендера тоже меняет условия для участников
+	if before.Status == models.PUBLISHED {
+		if changed := changedTenderFields(before, tender); len(changed) > 0 {
+			if err := recordAmendment(tx, tender, employee.ID, "Откат к версии "+strconv.Itoa(version), changed); err != nil {
+				log.Println("Ошибка сохранения изменения условий:", err)
+				http.Error(w, "Ошибка сохранения изменения условий тендера", http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка при сохранении откатанного тендера", http.StatusInternalServerError)
+		return
+	}
 
 	// Возвращаем все в нормальный вид (unmarshal)
 	utils.JSONFormat(w, r, tender)
 }
 
-// Фукнция которая переносит в бд все версии продукта по айдишникам
-func saveTenderVersion(tender models.Tender) {
+// TenderReportHandler возвращает отчет по тендерам организации.
+// @Summary Отчет по тендерам организации
+// @Description Возвращает количество тендеров организации по статусам и итогам: присужденные, закрытые без победителя, истекшие с предложениями и без них, отмененные.
+// @Tags Tenders
+// @Produce  json
+// @Param organizationId query int true "ID организации"
+// @Security BearerAuth
+// @Success 200 {object} models.TenderReport "Отчет по тендерам"
+// @Failure 400 {string} string "Неверный ID организации"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр тендеров организации"
+// @Failure 500 {string} string "Ошибка построения отчета"
+// @Router /tenders/report [get]
+func TenderReportHandler(w http.ResponseWriter, r *http.Request) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return
+	}
+	organizationID, err := strconv.Atoi(r.URL.Query().Get("organizationId"))
+	if err != nil {
+		http.Error(w, "Неверный ID организации", http.StatusBadRequest)
+		return
+	}
+	if !checkPermission(w, uint(organizationID), employee.ID, validators.ActionViewTender, "У вас нет прав просматривать тендеры организации") {
+		return
+	}
+
+	var rows []struct {
+		Status  models.TenderStatus
+		Outcome models.TenderOutcome
+		Count   int64
+	}
+	if err := utils.DB.Model(&models.Tender{}).
+		Select("status, outcome, COUNT(*) AS count").
+		Where("organization_id = ?", organizationID).
+		Group("status, outcome").
+		Scan(&rows).Error; err != nil {
+		log.Println("Ошибка построения отчета:", err)
+		http.Error(w, "Ошибка построения отчета", http.StatusInternalServerError)
+		return
+	}
+
+	report := models.TenderReport{OrganizationID: uint(organizationID)}
+	for _, row := range rows {
+		report.Total += row.Count
+		switch {
+		case row.Status == models.CREATED:
+			report.Created += row.Count
+		case row.Status == models.PUBLISHED:
+			report.Published += row.Count
+		case row.Status == models.CANCELLED:
+			report.Cancelled += row.Count
+		case row.Outcome == models.AWARDEDOutcome:
+			report.Awarded += row.Count
+		case row.Outcome == models.EXPIREDOutcome:
+			report.Expired += row.Count
+		case row.Outcome == models.EXPIREDNoBidsOutcome:
+			report.ExpiredWithoutBids += row.Count
+		default:
+			// Закрытые без итога (в том числе до появления отчетности) считаются закрытыми без победителя
+			report.NotAwarded += row.Count
+		}
+	}
+
+	utils.JSONFormat(w, r, report)
+}
+
+// PublishScheduleRequest время запланированной публикации тендера
+type PublishScheduleRequest struct {
+	PublishAt time.Time `json:"publishAt"`
+}
+
+// ScheduleTenderPublicationHandler планирует или переносит публикацию тендера.
+// @Summary Планирование публикации тендера
+// @Description Задает время, в которое тендер в статусе CREATED будет автоматически опубликован. Повторный вызов переносит публикацию. Если организация требует согласования, запланировать можно только согласованный тендер.
+// @Tags Tenders
+// @Accept  json
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Param schedule body PublishScheduleRequest true "Время публикации в формате RFC3339"
+// @Param If-Match header string false "ETag версии, которую видел клиент"
+// @Success 200 {object} models.Tender "Тендер с запланированной публикацией"
+// @Header 200 {string} ETag "Версия объекта"
+// @Failure 400 {string} string "Неверный ID тендера, время публикации, статус тендера или тендер не прошел согласование"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на публикацию тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 412 {string} string "Версия устарела, объект изменен другим пользователем"
+// @Failure 500 {string} string "Ошибка сохранения тендера"
+// @Router /tenders/{tenderId}/schedule [put]
+func ScheduleTenderPublicationHandler(w http.ResponseWriter, r *http.Request) {
+	// Тендер блокируется, чтобы не затереть публикацию, которую могла провести фоновая задача
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, ok := findScheduledTender(w, r, tx)
+	if !ok {
+		return
+	}
+	employee, _ := currentEmployee(w, r)
+
+	var request PublishScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		http.Error(w, "Неверный формат publishAt, ожидается RFC3339", http.StatusBadRequest)
+		return
+	}
+	if err := validators.CheckPublishAt(request.PublishAt, tender.SubmissionDeadline); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	// Несогласованный тендер фоновая задача все равно не опубликует
+	if err := checkTenderSignedOff(tender); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tender.PublishAt = &request.PublishAt
+	if !saveScheduledTender(w, tx, &tender, employee.ID) {
+		return
+	}
+	log.Printf("Публикация тендера %d запланирована на %s", tender.ID, request.PublishAt)
+
+	utils.JSONFormat(w, r, tender)
+}
+
+// CancelTenderPublicationHandler отменяет запланированную публикацию тендера.
+// @Summary Отмена запланированной публикации
+// @Description Снимает запланированную публикацию, тендер остается в статусе CREATED.
+// @Tags Tenders
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Param If-Match header string false "ETag версии, которую видел клиент"
+// @Success 200 {object} models.Tender "Тендер без запланированной публикации"
+// @Header 200 {string} ETag "Версия объекта"
+// @Failure 400 {string} string "Неверный ID тендера, статус тендера или публикация не запланирована"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на публикацию тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 412 {string} string "Версия устарела, объект изменен другим пользователем"
+// @Failure 500 {string} string "Ошибка сохранения тендера"
+// @Router /tenders/{tenderId}/schedule [delete]
+func CancelTenderPublicationHandler(w http.ResponseWriter, r *http.Request) {
+	tx := utils.DB.Begin()
+	defer tx.Rollback()
+	tender, ok := findScheduledTender(w, r, tx)
+	if !ok {
+		return
+	}
+	employee, _ := currentEmployee(w, r)
+	if tender.PublishAt == nil {
+		http.Error(w, "Публикация тендера не запланирована", http.StatusBadRequest)
+		return
+	}
+
+	tender.PublishAt = nil
+	if !saveScheduledTender(w, tx, &tender, employee.ID) {
+		return
+	}
+	log.Printf("Запланированная публикация тендера %d отменена", tender.ID)
+
+	utils.JSONFormat(w, r, tender)
+}
+
+// Находит и блокирует в транзакции тендер в статусе CREATED для управления публикацией и проверяет права,
+// при ошибке сам отвечает клиенту
+func findScheduledTender(w http.ResponseWriter, r *http.Request, tx *gorm.DB) (models.Tender, bool) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return models.Tender{}, false
+	}
+
+	tenderID, err := strconv.Atoi(mux.Vars(r)["tenderId"])
+	if err != nil {
+		http.Error(w, "Неверный тендер ID", http.StatusBadRequest)
+		return models.Tender{}, false
+	}
+
+	tender, err := lockTender(tx, uint(tenderID))
+	if err != nil {
+		writeLockError(w, err)
+		return models.Tender{}, false
+	}
+	if !checkPermission(w, tender.OrganizationID, employee.ID, validators.ActionPublishTender, "У вас нет прав публиковать этот тендер") {
+		return models.Tender{}, false
+	}
+	if !checkIfMatch(w, r, tender.Version) {
+		return models.Tender{}, false
+	}
+	if tender.Status != models.CREATED {
+		http.Error(w, "Тендер должен быть в статусе CREATED", http.StatusBadRequest)
+		return models.Tender{}, false
+	}
+	return tender, true
+}
+
+// Сохраняет тендер с измененным расписанием публикации новой версией и завершает транзакцию, при ошибке сам отвечает клиенту
+func saveScheduledTender(w http.ResponseWriter, tx *gorm.DB, tender *models.Tender, employeeID uint) bool {
+	tender.Version++
+	if err := tx.Save(tender).Error; err != nil {
+		http.Error(w, "Ошибка сохранения тендера", http.StatusInternalServerError)
+		return false
+	}
+	if err := saveTenderVersion(tx, *tender, &employeeID); err != nil {
+		http.Error(w, "Ошибка сохранения версии тендера", http.StatusInternalServerError)
+		return false
+	}
+	if err := tx.Commit().Error; err != nil {
+		http.Error(w, "Ошибка сохранения тендера", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// Фукнция которая переносит в бд все версии продукта по айдишникам, changedBy — автор изменения, nil для изменений самой системы
+func saveTenderVersion(tx *gorm.DB, tender models.Tender, changedBy *uint) error {
 	version := models.TenderVersion{
-		TenderID:    tender.ID,
-		Name:        tender.Name,
-		Description: tender.Description,
-		ServiceType: tender.ServiceType,
-		Status:      tender.Status,
-		Version:     tender.Version,
+		TenderID:           tender.ID,
+		Name:               tender.Name,
+		Description:        tender.Description,
+		ServiceType:        tender.ServiceType,
+		Status:             tender.Status,
+		Version:            tender.Version,
+		SubmissionDeadline: tender.SubmissionDeadline,
+		Budget:             tender.Budget,
+		Currency:           tender.Currency,
+		CancellationReason: tender.CancellationReason,
+		QuorumRule:         tender.QuorumRule,
+		QuorumValue:        tender.QuorumValue,
+		ChangedBy:          changedBy,
+	}
+
+	return tx.Create(&version).Error
+}
+
+// Публикует тендер и снимает запланированную публикацию, сохранение остается за вызывающим
+func publishTender(tender *models.Tender) {
+	tender.Status = models.PUBLISHED
+	tender.PublishAt = nil
+	if tender.Type == models.AUCTION {
+		startAuction(tender)
+	}
+	tender.Version++
+}
+
+// Запускает первый раунд аукциона с момента публикации
+func startAuction(tender *models.Tender) {
+	endsAt := time.Now().Add(time.Duration(tender.AuctionRoundSeconds) * time.Second)
+	tender.AuctionRound = 1
+	tender.AuctionRoundBids = 0
+	tender.AuctionRoundEndsAt = &endsAt
+}
+
+// Переносит тип тендера и параметры аукциона из запроса на редактирование, менять их можно только в статусе CREATED
+func applyAuctionSettings(tender *models.Tender, updated map[string]interface{}) error {
+	_, typeOk := updated["type"]
+	_, stepOk := updated["auctionStep"]
+	_, roundOk := updated["auctionRoundSeconds"]
+	_, extensionOk := updated["auctionExtensionSeconds"]
+	if !typeOk && !stepOk && !roundOk && !extensionOk {
+		return validators.ValidateAuction(tender)
+	}
+	if tender.Status != models.CREATED {
+		return errors.New("Тип тендера и параметры аукциона можно менять только в статусе CREATED")
+	}
+
+	if typeOk {
+		tenderType, ok := updated["type"].(string)
+		if !ok {
+			return errors.New("Неверный формат type, ожидается строка")
+		}
+		tender.Type = models.TenderType(tenderType)
+	}
+	if stepOk {
+		step, err := parseOptionalDecimal(updated["auctionStep"])
+		if err != nil {
+			return errors.New("Неверный формат auctionStep, ожидается число")
+		}
+		tender.AuctionStep = step
+	}
+	if roundOk {
+		seconds, err := parseJSONInt(updated["auctionRoundSeconds"])
+		if err != nil {
+			return errors.New("Неверный формат auctionRoundSeconds, ожидается целое число")
+		}
+		tender.AuctionRoundSeconds = seconds
+	}
+	if extensionOk {
+		seconds, err := parseJSONInt(updated["auctionExtensionSeconds"])
+		if err != nil {
+			return errors.New("Неверный формат auctionExtensionSeconds, ожидается целое число")
+		}
+		tender.AuctionExtensionSeconds = seconds
+	}
+	return validators.ValidateAuction(tender)
+}
+
+// Применяет правило кворума из запроса на редактирование, null в quorumRule возвращает правило организации
+func applyQuorumSettings(tender *models.Tender, updated map[string]interface{}) error {
+	rule, ruleOk := updated["quorumRule"]
+	value, valueOk := updated["quorumValue"]
+	if !ruleOk && !valueOk {
+		return nil
+	}
+	if tender.Status != models.CREATED {
+		return errors.New("Правило кворума можно менять только в статусе CREATED")
+	}
+
+	if ruleOk {
+		if rule == nil {
+			tender.QuorumRule = ""
+		} else {
+			quorumRule, ok := rule.(string)
+			if !ok {
+				return errors.New("Неверный формат quorumRule, ожидается строка")
+			}
+			tender.QuorumRule = models.QuorumRule(quorumRule)
+		}
+	}
+	if valueOk {
+		quorumValue, err := parseJSONInt(value)
+		if err != nil {
+			return errors.New("Неверный формат quorumValue, ожидается целое число")
+		}
+		tender.QuorumValue = quorumValue
+	}
+	return inheritQuorumRule(tender)
+}
+
+// Тендер без своего правила кворума получает текущее правило организации
+func inheritQuorumRule(tender *models.Tender) error {
+	if tender.QuorumRule == "" {
+		var organization models.Organization
+		if err := utils.DB.First(&organization, tender.OrganizationID).Error; err != nil {
+			return errors.New("Организация тендера не найдена")
+		}
+		tender.QuorumRule = organization.QuorumRule
+		tender.QuorumValue = organization.QuorumValue
+	}
+	return validators.CheckQuorumRule(&tender.QuorumRule, &tender.QuorumValue)
+}
+
+// Разбирает целое число из JSON-запроса, прочитанного с UseNumber: секунды аукциона, maxWinners, quorumValue
+func parseJSONInt(value interface{}) (int, error) {
+	number, ok := value.(json.Number)
+	if !ok {
+		return 0, errors.New("ожидается целое число")
+	}
+	parsed, err := strconv.Atoi(number.String())
+	if err != nil {
+		return 0, err
+	}
+	return parsed, nil
+}
+
+// Закрывает тендер и отменяет все его незавершенные предложения, сохранение самого тендера остается за вызывающим
+func closeTender(tx *gorm.DB, tender *models.Tender) error {
+	tender.Status = models.CLOSED
+	if err := cancelOpenBidsAndLots(tx, tender.ID); err != nil {
+		return err
+	}
+
+	// Итог закрытия: было ли принято хотя бы одно предложение
+	var awarded int64
+	if err := tx.Model(&models.Bid{}).Where("tender_id = ? AND status = ?", tender.ID, models.PUBLISHEDBid).
+		Count(&awarded).Error; err != nil {
+		return err
+	}
+	tender.Outcome = models.NOTAWARDEDOutcome
+	if awarded > 0 {
+		tender.Outcome = models.AWARDEDOutcome
+	}
+	tender.Version++
+	return nil
+}
+
+// Отмечает, что тендер закрыт по истечении срока подачи, и различает случаи с предложениями и без них
+func markTenderExpired(tx *gorm.DB, tender *models.Tender) error {
+	if tender.Outcome == models.AWARDEDOutcome {
+		return nil
+	}
+	var bids int64
+	if err := tx.Model(&models.Bid{}).Where("tender_id = ?", tender.ID).Count(&bids).Error; err != nil {
+		return err
 	}
+	tender.Outcome = models.EXPIREDOutcome
+	if bids == 0 {
+		tender.Outcome = models.EXPIREDNoBidsOutcome
+	}
+	return nil
+}
+
+// Отменяет тендер с указанием причины вместе с его незавершенными предложениями и лотами, сохранение самого тендера остается за вызывающим
+func cancelTender(tx *gorm.DB, tender *models.Tender, reason string) error {
+	tender.Status = models.CANCELLED
+	tender.CancellationReason = reason
+	tender.Outcome = models.CANCELLEDOutcome
+	tender.PublishAt = nil
+	tender.AuctionRoundEndsAt = nil
+	if err := cancelOpenBidsAndLots(tx, tender.ID); err != nil {
+		return err
+	}
+	tender.Version++
+	return nil
+}
+
+// Тендер виден ответственным за организацию, а после публикации — всем (для INVITE_ONLY — только приглашенным)
+func canViewTender(tender models.Tender, employee *models.Employee) bool {
+	if employee != nil && validators.CheckPermission(tender.OrganizationID, employee.ID, validators.ActionViewTender) == nil {
+		return true
+	}
+	if tender.Status == models.CREATED {
+		return false
+	}
+	if tender.Visibility == models.INVITEONLYVisibility {
+		return employee != nil && tenderInvitesEmployee(tender, employee.ID)
+	}
+	return true
+}
 
-	utils.DB.Create(&version)
+// Тендер закрыт или отменен, дальнейшие изменения невозможны
+func tenderFinished(tender models.Tender) bool {
+	return tender.Status == models.CLOSED || tender.Status == models.CANCELLED
+}
+
+// Отменяет незавершенные предложения и лоты тендера, по которым не успели принять решение
+func cancelOpenBidsAndLots(tx *gorm.DB, tenderID uint) error {
+	if err := cancelOpenBids(tx, tenderID, nil); err != nil {
+		return err
+	}
+	return tx.Model(&models.Lot{}).Where("tender_id = ? AND status = ?", tenderID, models.OPENLot).
+		Update("status", models.CANCELLEDLot).Error
+}
+
+// Отменяет незавершенные предложения тендера, при заданном lotID — только предложения этого лота
+func cancelOpenBids(tx *gorm.DB, tenderID uint, lotID *uint) error {
+	query := tx.Where("tender_id = ?", tenderID)
+	if lotID != nil {
+		query = query.Where("lot_id = ?", *lotID)
+	}
+	var bids []models.Bid
+	if err := query.Find(&bids).Error; err != nil {
+		return err
+	}
+
+	for _, bid := range bids {
+		if bid.Status != models.CANCELED && bid.Status != models.PUBLISHEDBid {
+			bid.Status = models.CANCELED
+			bid.Version++
+			if err := tx.Save(&bid).Error; err != nil {
+				return err
+			}
+			if err := saveBidsVersion(tx, bid, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Разбирает необязательную денежную сумму из JSON: null, число или строка
+func parseOptionalDecimal(value interface{}) (decimal.NullDecimal, error) {
+	switch v := value.(type) {
+	case nil:
+		return decimal.NullDecimal{}, nil
+	case json.Number:
+		parsed, err := decimal.NewFromString(v.String())
+		return decimal.NewNullDecimal(parsed), err
+	case string:
+		parsed, err := decimal.NewFromString(v)
+		return decimal.NewNullDecimal(parsed), err
+	default:
+		return decimal.NullDecimal{}, errors.New("ожидается число")
+	}
+}
+
+// Разбирает необязательное время из JSON: null или строка в формате RFC3339
+func parseOptionalTime(value interface{}) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return nil, errors.New("ожидается строка")
+	}
+	parsed, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
 }
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"log"
+	"net/http"
+	"testAvito/models"
+)
+
+var (
+	errTenderNotFound = errors.New("Тендер не найден")
+	errBidNotFound    = errors.New("Предложение не найдено")
+)
+
+// Запрос с блокировкой строк SELECT ... FOR UPDATE до конца транзакции
+func forUpdate(tx *gorm.DB) *gorm.DB {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"})
+}
+
+// Блокирует тендер в транзакции. Ошибки базы (взаимоблокировка, обрыв соединения) возвращаются как есть
+func lockTender(tx *gorm.DB, tenderID uint) (models.Tender, error) {
+	var tender models.Tender
+	err := forUpdate(tx).First(&tender, tenderID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Tender{}, errTenderNotFound
+	}
+	if err != nil {
+		return models.Tender{}, err
+	}
+	return tender, nil
+}
+
+// Блокирует предложение вместе с его тендером. Тендер блокируется первым, как и в ручках тендера,
+// чтобы параллельные транзакции не ждали друг друга по кругу
+func lockBidWithTender(tx *gorm.DB, bidID uint) (models.Bid, models.Tender, error) {
+	var ref models.Bid
+	err := tx.Select("tender_id").First(&ref, bidID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Bid{}, models.Tender{}, errBidNotFound
+	}
+	if err != nil {
+		return models.Bid{}, models.Tender{}, err
+	}
+	tender, err := lockTender(tx, ref.TenderID)
+	if err != nil {
+		return models.Bid{}, models.Tender{}, err
+	}
+	var bid models.Bid
+	err = forUpdate(tx).First(&bid, bidID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return models.Bid{}, models.Tender{}, errBidNotFound
+	}
+	if err != nil {
+		return models.Bid{}, models.Tender{}, err
+	}
+	return bid, tender, nil
+}
+
+// Отвечает клиенту на ошибку блокировки: 404, если тендера или предложения нет, иначе 500
+func writeLockError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errTenderNotFound) || errors.Is(err, errBidNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	log.Println("Ошибка блокировки строк:", err)
+	http.Error(w, "Ошибка базы данных", http.StatusInternalServerError)
+}
@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"net/http"
+	"strconv"
+	"testAvito/models"
+	"testAvito/utils"
+	"testAvito/validators"
+)
+
+// GetTenderVersionsHandler возвращает историю версий тендера.
+// @Summary История версий тендера
+// @Description Возвращает все сохраненные версии тендера по возрастанию номера: время создания версии CreatedAt и автора изменения ChangedBy (пусто для изменений, сделанных системой, например публикации по расписанию или закрытия по кворуму).
+// @Tags Versions
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Security BearerAuth
+// @Success 200 {array} models.TenderVersion "Версии тендера"
+// @Failure 400 {string} string "Неверный ID тендера"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр тендера"
+// @Failure 404 {string} string "Тендер не найден"
+// @Failure 500 {string} string "Ошибка загрузки версий"
+// @Router /tenders/{tenderId}/versions [get]
+func GetTenderVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findTenderForAction(w, r, validators.ActionViewTender, "У вас нет прав просматривать историю тендера.")
+	if !ok {
+		return
+	}
+
+	var versions []models.TenderVersion
+	if err := utils.DB.Where("tender_id = ?", tender.ID).Order("version, id").Find(&versions).Error; err != nil {
+		http.Error(w, "Ошибка загрузки версий тендера", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, versions)
+}
+
+// GetTenderVersionsDiffHandler сравнивает две версии тендера.
+// @Summary Сравнение версий тендера
+// @Description Возвращает поля, которые отличаются между версиями from и to, со значениями в каждой из них. Помогает проверить изменения перед откатом.
+// @Tags Versions
+// @Produce  json
+// @Param tenderId path int true "ID тендера"
+// @Param from query int true "Исходная версия"
+// @Param to query int true "Версия для сравнения"
+// @Security BearerAuth
+// @Success 200 {object} models.VersionDiff "Разница версий"
+// @Failure 400 {string} string "Неверный ID тендера или номер версии"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр тендера"
+// @Failure 404 {string} string "Тендер или версия не найдены"
+// @Failure 500 {string} string "Ошибка загрузки версий"
+// @Router /tenders/{tenderId}/versions/diff [get]
+func GetTenderVersionsDiffHandler(w http.ResponseWriter, r *http.Request) {
+	tender, ok := findTenderForAction(w, r, validators.ActionViewTender, "У вас нет прав просматривать историю тендера.")
+	if !ok {
+		return
+	}
+	from, to, ok := versionRange(w, r)
+	if !ok {
+		return
+	}
+
+	var before, after models.TenderVersion
+	if !findVersion(w, utils.DB.Where("tender_id = ? AND version = ?", tender.ID, from), &before) ||
+		!findVersion(w, utils.DB.Where("tender_id = ? AND version = ?", tender.ID, to), &after) {
+		return
+	}
+	utils.JSONFormat(w, r, models.VersionDiff{FromVersion: from, ToVersion: to, Changes: diffTenderVersions(before, after)})
+}
+
+// GetBidVersionsHandler возвращает историю версий предложения.
+// @Summary История версий предложения
+// @Description Возвращает все сохраненные версии предложения по возрастанию номера со временем и автором изменения. Доступно автору предложения и ответственным за тендер, для закрытого тендера — после вскрытия предложений.
+// @Tags Versions
+// @Produce  json
+// @Param bidId path int true "ID предложения"
+// @Security BearerAuth
+// @Success 200 {array} models.BidVersion "Версии предложения"
+// @Failure 400 {string} string "Неверный ID предложения"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр предложения"
+// @Failure 404 {string} string "Предложение или тендер не найдены"
+// @Failure 500 {string} string "Ошибка загрузки версий"
+// @Router /bids/{bidId}/versions [get]
+func GetBidVersionsHandler(w http.ResponseWriter, r *http.Request) {
+	bid, ok := findViewableBid(w, r)
+	if !ok {
+		return
+	}
+
+	var versions []models.BidVersion
+	if err := utils.DB.Where("bid_id = ?", bid.ID).Order("version, id").Find(&versions).Error; err != nil {
+		http.Error(w, "Ошибка загрузки версий предложения", http.StatusInternalServerError)
+		return
+	}
+	utils.JSONFormat(w, r, versions)
+}
+
+// GetBidVersionsDiffHandler сравнивает две версии предложения.
+// @Summary Сравнение версий предложения
+// @Description Возвращает поля, которые отличаются между версиями from и to, со значениями в каждой из них.
+// @Tags Versions
+// @Produce  json
+// @Param bidId path int true "ID предложения"
+// @Param from query int true "Исходная версия"
+// @Param to query int true "Версия для сравнения"
+// @Security BearerAuth
+// @Success 200 {object} models.VersionDiff "Разница версий"
+// @Failure 400 {string} string "Неверный ID предложения или номер версии"
+// @Failure 401 {string} string "Необходима авторизация"
+// @Failure 403 {string} string "Нет прав на просмотр предложения"
+// @Failure 404 {string} string "Предложение, тендер или версия не найдены"
+// @Failure 500 {string} string "Ошибка загрузки версий"
+// @Router /bids/{bidId}/versions/diff [get]
+func GetBidVersionsDiffHandler(w http.ResponseWriter, r *http.Request) {
+	bid, ok := findViewableBid(w, r)
+	if !ok {
+		return
+	}
+	from, to, ok := versionRange(w, r)
+	if !ok {
+		return
+	}
+
+	var before, after models.BidVersion
+	if !findVersion(w, utils.DB.Where("bid_id = ? AND version = ?", bid.ID, from), &before) ||
+		!findVersion(w, utils.DB.Where("bid_id = ? AND version = ?", bid.ID, to), &after) {
+		return
+	}
+	utils.JSONFormat(w, r, models.VersionDiff{FromVersion: from, ToVersion: to, Changes: diffBidVersions(before, after)})
+}
+
+// Находит предложение, историю которого может смотреть текущий сотрудник, при ошибке сам отвечает клиенту
+func findViewableBid(w http.ResponseWriter, r *http.Request) (models.Bid, bool) {
+	employee, ok := currentEmployee(w, r)
+	if !ok {
+		return models.Bid{}, false
+	}
+	bid, tender, ok := findBidWithTender(w, r)
+	if !ok {
+		return models.Bid{}, false
+	}
+	if !canViewBidDetails(bid, tender, employee.ID) {
+		http.Error(w, "У вас нет прав просматривать историю предложения.", http.StatusForbidden)
+		return models.Bid{}, false
+	}
+	return bid, true
+}
+
+// Читает номера сравниваемых версий from и to из запроса
+func versionRange(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil || from < 1 {
+		http.Error(w, "Неверная версия from", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	to, err := strconv.Atoi(r.URL.Query().Get("to"))
+	if err != nil || to < 1 {
+		http.Error(w, "Неверная версия to", http.StatusBadRequest)
+		return 0, 0, false
+	}
+	return from, to, true
+}
+
+// Загружает последнюю запись версии по условию запроса, при ошибке сам отвечает клиенту
+func findVersion(w http.ResponseWriter, query *gorm.DB, version interface{}) bool {
+	err := query.Order("id DESC").First(version).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		http.Error(w, "Версия не найдена", http.StatusNotFound)
+		return false
+	}
+	if err != nil {
+		http.Error(w, "Ошибка загрузки версий", http.StatusInternalServerError)
+		return false
+	}
+	return true
+}
+
+// Поля тендера, которые отличаются между двумя версиями
+func diffTenderVersions(before, after models.TenderVersion) []models.VersionChange {
+	changes := []models.VersionChange{}
+	changes = appendChange(changes, "name", before.Name, after.Name, before.Name == after.Name)
+	changes = appendChange(changes, "description", before.Description, after.Description, before.Description == after.Description)
+	changes = appendChange(changes, "serviceType", before.ServiceType, after.ServiceType, before.ServiceType == after.ServiceType)
+	changes = appendChange(changes, "status", before.Status, after.Status, before.Status == after.Status)
+	changes = appendChange(changes, "submissionDeadline", before.SubmissionDeadline, after.SubmissionDeadline,
+		sameTime(before.SubmissionDeadline, after.SubmissionDeadline))
+	changes = appendChange(changes, "budget", before.Budget, after.Budget, sameDecimal(before.Budget, after.Budget))
+	changes = appendChange(changes, "currency", before.Currency, after.Currency, before.Currency == after.Currency)
+	changes = appendChange(changes, "cancellationReason", before.CancellationReason, after.CancellationReason,
+		before.CancellationReason == after.CancellationReason)
+	changes = appendChange(changes, "quorumRule", before.QuorumRule, after.QuorumRule, before.QuorumRule == after.QuorumRule)
+	changes = appendChange(changes, "quorumValue", before.QuorumValue, after.QuorumValue, before.QuorumValue == after.QuorumValue)
+	return changes
+}
+
+// Поля предложения, которые отличаются между двумя версиями
+func diffBidVersions(before, after models.BidVersion) []models.VersionChange {
+	changes := []models.VersionChange{}
+	changes = appendChange(changes, "name", before.Name, after.Name, before.Name == after.Name)
+	changes = appendChange(changes, "description", before.Description, after.Description, before.Description == after.Description)
+	changes = appendChange(changes, "status", before.Status, after.Status, before.Status == after.Status)
+	changes = appendChange(changes, "amount", before.Amount, after.Amount, sameDecimal(before.Amount, after.Amount))
+	return changes
+}
+
+func appendChange(changes []models.VersionChange, field string, from, to interface{}, same bool) []models.VersionChange {
+	if same {
+		return changes
+	}
+	return append(changes, models.VersionChange{Field: field, From: from, To: to})
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"gorm.io/gorm"
+	"log"
+	"testAvito/models"
+	"testAvito/utils"
+	"time"
+)
+
+// StartTenderWorker периодически выполняет фоновые задачи по тендерам. Блокирует вызывающего, запускать в отдельной горутине.
+func StartTenderWorker(interval time.Duration) {
+	log.Printf("Фоновые задачи по тендерам запускаются каждые %s", interval)
+	runTenderJobs()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		runTenderJobs()
+	}
+}
+
+func runTenderJobs() {
+	publishScheduledTenders()
+	closeExpiredTenders()
+	finishAuctionRounds()
+}
+
+// Публикует тендеры, время запланированной публикации которых наступило
+func publishScheduledTenders() {
+	var tenders []models.Tender
+	if err := utils.DB.Where("status = ? AND publish_at IS NOT NULL AND publish_at <= ?", models.CREATED, time.Now()).
+		Find(&tenders).Error; err != nil {
+		log.Println("Ошибка поиска тендеров для публикации:", err)
+		return
+	}
+
+	for _, scheduled := range tenders {
+		err := withLockedTender(scheduled.ID, func(tx *gorm.DB, tender *models.Tender) error {
+			if tender.Status != models.CREATED || tender.PublishAt == nil || tender.PublishAt.After(time.Now()) {
+				return nil
+			}
+			// Согласование могли сбросить после планирования. Публикация снимается, иначе тендер
+			// выбирался бы на каждом запуске; после согласования ее нужно запланировать заново
+			if err := checkTenderSignedOff(*tender); err != nil {
+				tender.PublishAt = nil
+				tender.Version++
+				if err := tx.Save(tender).Error; err != nil {
+					return err
+				}
+				if err := saveTenderVersion(tx, *tender, nil); err != nil {
+					return err
+				}
+				log.Printf("Тендер %d не опубликован по расписанию, публикация снята: %v", tender.ID, err)
+				return nil
+			}
+			publishTender(tender)
+			if err := tx.Save(tender).Error; err != nil {
+				return err
+			}
+			if err := saveTenderVersion(tx, *tender, nil); err != nil {
+				return err
+			}
+			log.Printf("Тендер %d опубликован по расписанию", tender.ID)
+			return nil
+		})
+		if err != nil {
+			log.Printf("Ошибка публикации тендера %d: %v", scheduled.ID, err)
+		}
+	}
+}
+
+// Закрывает опубликованные тендеры, у которых истек срок подачи предложений.
+// Закрытый (sealed) тендер с предложениями не закрывается: после срока подачи предложения вскрываются
+// и по ним голосуют, тендер закрывается по кворуму или вручную
+func closeExpiredTenders() {
+	var tenders []models.Tender
+	if err := utils.DB.Where("status = ? AND submission_deadline IS NOT NULL AND submission_deadline <= ?", models.PUBLISHED, time.Now()).
+		Find(&tenders).Error; err != nil {
+		log.Println("Ошибка поиска просроченных тендеров:", err)
+		return
+	}
+
+	for _, expired := range tenders {
+		err := withLockedTender(expired.ID, func(tx *gorm.DB, tender *models.Tender) error {
+			if tender.Status != models.PUBLISHED || tender.SubmissionDeadline == nil || tender.SubmissionDeadline.After(time.Now()) {
+				return nil
+			}
+			if tender.Sealed {
+				var openBids int64
+				if err := tx.Model(&models.Bid{}).Where("tender_id = ? AND status = ?", tender.ID, models.CREATEDBid).
+					Count(&openBids).Error; err != nil {
+					return err
+				}
+				if openBids > 0 {
+					return nil
+				}
+			}
+			if err := closeTender(tx, tender); err != nil {
+				return err
+			}
+			if err := markTenderExpired(tx, tender); err != nil {
+				return err
+			}
+			if err := tx.Save(tender).Error; err != nil {
+				return err
+			}
+			if err := saveTenderVersion(tx, *tender, nil); err != nil {
+				return err
+			}
+			log.Printf("Тендер %d закрыт по истечении срока подачи предложений", tender.ID)
+			return nil
+		})
+		if err != nil {
+			log.Printf("Ошибка закрытия тендера %d: %v", expired.ID, err)
+		}
+	}
+}
+
+// Подводит итоги истекших раундов аукционов: если цену снижали, начинается новый раунд, иначе аукцион завершается
+func finishAuctionRounds() {
+	var tenders []models.Tender
+	if err := utils.DB.Where("status = ? AND type = ? AND auction_round_ends_at IS NOT NULL AND auction_round_ends_at <= ?", models.PUBLISHED, models.AUCTION, time.Now()).
+		Find(&tenders).Error; err != nil {
+		log.Println("Ошибка поиска аукционов с истекшим раундом:", err)
+		return
+	}
+
+	for _, auction := range tenders {
+		err := withLockedTender(auction.ID, func(tx *gorm.DB, tender *models.Tender) error {
+			// Раунд могли продлить ставкой, пока шла выборка
+			if tender.Status != models.PUBLISHED || tender.AuctionRoundEndsAt == nil || tender.AuctionRoundEndsAt.After(time.Now()) {
+				return nil
+			}
+			if tender.AuctionRoundBids > 0 {
+				endsAt := time.Now().Add(time.Duration(tender.AuctionRoundSeconds) * time.Second)
+				tender.AuctionRound++
+				tender.AuctionRoundBids = 0
+				tender.AuctionRoundEndsAt = &endsAt
+				if err := tx.Save(tender).Error; err != nil {
+					return err
+				}
+				log.Printf("По тендеру %d начался раунд аукциона %d", tender.ID, tender.AuctionRound)
+				return nil
+			}
+			return finishAuction(tx, tender)
+		})
+		if err != nil {
+			log.Printf("Ошибка подведения итогов раунда аукциона %d: %v", auction.ID, err)
+		}
+	}
+}
+
+// Выполняет fn в транзакции над заблокированным и перечитанным тендером, условия задачи fn проверяет заново
+func withLockedTender(tenderID uint, fn func(tx *gorm.DB, tender *models.Tender) error) error {
+	return utils.DB.Transaction(func(tx *gorm.DB) error {
+		tender, err := lockTender(tx, tenderID)
+		if err != nil {
+			return err
+		}
+		return fn(tx, &tender)
+	})
+}
@@ -1,6 +1,19 @@
 package middleware
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testAvito/models"
+	"testAvito/utils"
+)
+
+type contextKey string
+
+const employeeKey contextKey = "employee"
+
+// Параметры, через которые раньше передавалось имя текущего пользователя
+var legacyIdentityParams = []string{"username", "requesterUsername"}
 
 func JSONMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -8,3 +21,49 @@ func JSONMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// AuthMiddleware проверяет bearer-токен и кладет найденного сотрудника в контекст запроса.
+// Запросы без заголовка Authorization пропускаются дальше анонимно, обработчики сами решают, нужен ли им пользователь.
+func AuthMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for _, param := range legacyIdentityParams {
+			if r.URL.Query().Has(param) {
+				http.Error(w, "Параметр "+param+" больше не поддерживается, используйте заголовок Authorization: Bearer <token>", http.StatusBadRequest)
+				return
+			}
+		}
+
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		tokenString, found := strings.CutPrefix(header, "Bearer ")
+		if !found || tokenString == "" {
+			http.Error(w, "Неверный формат заголовка Authorization", http.StatusUnauthorized)
+			return
+		}
+
+		claims, err := utils.ParseToken(tokenString)
+		if err != nil {
+			http.Error(w, "Недействительный токен", http.StatusUnauthorized)
+			return
+		}
+
+		var employee models.Employee
+		if err := utils.DB.First(&employee, claims.EmployeeID).Error; err != nil {
+			http.Error(w, "Пользователь токена не найден", http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), employeeKey, employee)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// EmployeeFromContext возвращает сотрудника, авторизованного через AuthMiddleware
+func EmployeeFromContext(ctx context.Context) (models.Employee, bool) {
+	employee, ok := ctx.Value(employeeKey).(models.Employee)
+	return employee, ok
+}
@@ -0,0 +1,21 @@
+package models
+
+import "time"
+
+// Attachment файл, приложенный к тендеру или предложению; содержимое лежит в хранилище под StorageKey
+type Attachment struct {
+	ID          uint      `gorm:"primaryKey" json:"id"`
+	TenderID    *uint     `gorm:"index" json:"tenderId,omitempty"`
+	BidID       *uint     `gorm:"index" json:"bidId,omitempty"`
+	FileName    string    `gorm:"not null" json:"fileName"`
+	ContentType string    `gorm:"not null" json:"contentType"`
+	Size        int64     `gorm:"not null" json:"size"`
+	SHA256      string    `gorm:"type:varchar(64);not null" json:"sha256"`
+	StorageKey  string    `gorm:"not null;uniqueIndex" json:"-"`
+	UploaderID  uint      `gorm:"not null" json:"uploaderId"`
+	CreatedAt   time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (Attachment) TableName() string {
+	return "attachments"
+}
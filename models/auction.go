@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// AuctionPosition место предложения в текущем рейтинге аукциона
+type AuctionPosition struct {
+	Place     int             `json:"place"`
+	BidID     uint            `json:"bidId,omitempty"`
+	Own       bool            `json:"own"`
+	Amount    decimal.Decimal `json:"amount" swaggertype:"string"`
+	UpdatedAt time.Time       `json:"updated_at"`
+}
+
+// AuctionRanking текущий рейтинг предложений аукциона на понижение
+type AuctionRanking struct {
+	TenderID    uint                `json:"tenderId"`
+	Status      TenderStatus        `json:"status"`
+	Round       int                 `json:"round"`
+	RoundEndsAt *time.Time          `json:"round_ends_at"`
+	Step        decimal.NullDecimal `json:"step" swaggertype:"string"`
+	Currency    string              `json:"currency"`
+	Positions   []AuctionPosition   `json:"positions"`
+}
@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type BidStatus string
 
@@ -20,16 +24,42 @@ const (
 )
 
 type Bid struct {
-	ID          uint           `gorm:"primaryKey" json:"id"`
-	Name        string         `gorm:"not null" json:"name"`
-	Description string         `json:"description"`
-	Status      BidStatus      `gorm:"type:bid_status;default:'CREATED'" json:"status"`
-	TenderID    uint           `gorm:"not null" json:"tenderId"`
-	AuthorType  AuthorBidsType `gorm:"not null" json:"author_type"`
-	AuthorID    uint           `gorm:"not null" json:"author_id"`
-	Version     int            `gorm:"default:1" json:"version"`
-	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	ID          uint                `gorm:"primaryKey" json:"id"`
+	Name        string              `gorm:"not null" json:"name"`
+	Description string              `json:"description"`
+	Status      BidStatus           `gorm:"type:bid_status;default:'CREATED'" json:"status"`
+	TenderID    uint                `gorm:"not null" json:"tenderId"`
+	LotID       *uint               `gorm:"index" json:"lotId"`
+	AuthorType  AuthorBidsType      `gorm:"not null" json:"author_type"`
+	AuthorID    uint                `gorm:"not null" json:"author_id"`
+	Amount      decimal.NullDecimal `gorm:"type:numeric(20,2)" json:"amount" swaggertype:"string"`
+	Version     int                 `gorm:"default:1" json:"version"`
+	// Условия тендера изменились после подачи, автор должен подтвердить или изменить предложение
+	NeedsReconfirmation bool `gorm:"not null;default:false" json:"needs_reconfirmation"`
+	// Доля (в процентах) и объем поставки, присужденные победившему предложению
+	AwardShare    decimal.NullDecimal `gorm:"type:numeric(5,2)" json:"award_share" swaggertype:"string"`
+	AwardQuantity decimal.NullDecimal `gorm:"type:numeric(20,2)" json:"award_quantity" swaggertype:"string"`
+	CreatedAt     time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt     time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+// BidMetadata данные предложения, которые видны до вскрытия закрытого (sealed) тендера
+type BidMetadata struct {
+	ID        uint      `json:"id"`
+	TenderID  uint      `json:"tenderId"`
+	LotID     *uint     `json:"lotId"`
+	Status    BidStatus `json:"status"`
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SealedBids ответ по закрытому тендеру до вскрытия предложений
+type SealedBids struct {
+	Sealed   bool          `json:"sealed"`
+	OpensAt  *time.Time    `json:"opens_at"`
+	Count    int           `json:"count"`
+	Metadata []BidMetadata `json:"bids"`
 }
 
 func (Bid) TableName() string {
@@ -1,18 +1,26 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type BidVersion struct {
-	BidID       uint           `gorm:"primaryKey"`
-	Name        string         `gorm:"not null" json:"name"`
-	Description string         `json:"description"`
-	Status      BidStatus      `gorm:"type:bid_status;default:'CREATED'" json:"status"`
-	TenderID    uint           `gorm:"not null" json:"tenderId"`
-	AuthorType  AuthorBidsType `gorm:"not null" json:"author_type"`
-	AuthorID    uint           `gorm:"not null" json:"author_id"`
-	Version     int            `gorm:"default:1" json:"version"`
-	CreatedAt   time.Time      `gorm:"autoCreateTime" json:"created_at"`
-	UpdatedAt   time.Time      `gorm:"autoUpdateTime" json:"updated_at"`
+	ID          uint                `gorm:"primaryKey" json:"id"`
+	BidID       uint                `gorm:"not null;index" json:"bidId"`
+	Name        string              `gorm:"not null" json:"name"`
+	Description string              `json:"description"`
+	Status      BidStatus           `gorm:"type:bid_status;default:'CREATED'" json:"status"`
+	TenderID    uint                `gorm:"not null" json:"tenderId"`
+	AuthorType  AuthorBidsType      `gorm:"not null" json:"author_type"`
+	AuthorID    uint                `gorm:"not null" json:"author_id"`
+	Amount      decimal.NullDecimal `gorm:"type:numeric(20,2)" json:"amount" swaggertype:"string"`
+	Version     int                 `gorm:"default:1" json:"version"`
+	// Сотрудник, внесший изменение; пусто для изменений, сделанных системой
+	ChangedBy *uint     `json:"changedBy"`
+	CreatedAt time.Time `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime" json:"updated_at"`
 }
 
 func (BidVersion) TableName() string {
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Максимальная оценка предложения по одному критерию
+const MaxCriterionScore = 10
+
+// EvaluationCriterion взвешенный критерий оценки предложений тендера (цена, сроки, опыт и т.д.)
+type EvaluationCriterion struct {
+	ID          uint            `gorm:"primaryKey" json:"id"`
+	TenderID    uint            `gorm:"not null;index" json:"tenderId"`
+	Name        string          `gorm:"not null" json:"name"`
+	Description string          `json:"description"`
+	Weight      decimal.Decimal `gorm:"type:numeric(10,2);not null" json:"weight" swaggertype:"string"`
+	CreatedAt   time.Time       `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (EvaluationCriterion) TableName() string {
+	return "evaluation_criteria"
+}
+
+// BidScore оценка предложения по критерию от одного ответственного
+type BidScore struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	BidID         uint      `gorm:"not null;uniqueIndex:idx_bid_score" json:"bidId"`
+	CriterionID   uint      `gorm:"not null;uniqueIndex:idx_bid_score" json:"criterionId"`
+	ResponsibleID uint      `gorm:"not null;uniqueIndex:idx_bid_score" json:"responsibleId"`
+	Score         int       `gorm:"not null" json:"score"`
+	UpdatedAt     time.Time `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (BidScore) TableName() string {
+	return "bid_scores"
+}
+
+// CriterionResult средняя оценка предложения по критерию
+type CriterionResult struct {
+	CriterionID  uint            `json:"criterionId"`
+	Name         string          `json:"name"`
+	Weight       decimal.Decimal `json:"weight" swaggertype:"string"`
+	AverageScore decimal.Decimal `json:"average_score" swaggertype:"string"`
+	Votes        int64           `json:"votes"`
+}
+
+// BidEvaluation место предложения в рейтинге по взвешенным критериям
+type BidEvaluation struct {
+	Rank       int               `json:"rank"`
+	BidID      uint              `json:"bidId"`
+	LotID      *uint             `json:"lotId"`
+	Name       string            `json:"name"`
+	Status     BidStatus         `json:"status"`
+	TotalScore decimal.Decimal   `json:"total_score" swaggertype:"string"`
+	Scored     bool              `json:"scored"`
+	Criteria   []CriterionResult `json:"criteria"`
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+type LotStatus string
+
+const (
+	OPENLot      LotStatus = "OPEN"
+	AWARDEDLot   LotStatus = "AWARDED"
+	CANCELLEDLot LotStatus = "CANCELLED"
+)
+
+// Lot независимая часть тендера, по которой предложения подаются и принимаются отдельно
+type Lot struct {
+	ID          uint                `gorm:"primaryKey" json:"id"`
+	TenderID    uint                `gorm:"not null;index" json:"tenderId"`
+	Name        string              `gorm:"not null" json:"name"`
+	Description string              `json:"description"`
+	Budget      decimal.NullDecimal `gorm:"type:numeric(20,2)" json:"budget" swaggertype:"string"`
+	Status      LotStatus           `gorm:"type:varchar(16);not null;default:'OPEN'" json:"status"`
+	WinnerBidID *uint               `json:"winnerBidId"`
+	CreatedAt   time.Time           `gorm:"autoCreateTime" json:"created_at"`
+	UpdatedAt   time.Time           `gorm:"autoUpdateTime" json:"updated_at"`
+}
+
+func (Lot) TableName() string {
+	return "lots"
+}
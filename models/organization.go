@@ -10,13 +10,28 @@ const (
 	JSC OrganizationType = "JSC"
 )
 
+// QuorumRule правило, по которому считается число утверждений для принятия предложения
+type QuorumRule string
+
+const (
+	FIXEDQuorum     QuorumRule = "FIXED"
+	PERCENTQuorum   QuorumRule = "PERCENT"
+	MAJORITYQuorum  QuorumRule = "MAJORITY"
+	UNANIMOUSQuorum QuorumRule = "UNANIMOUS"
+)
+
 type Organization struct {
 	ID          uint   `gorm:"primaryKey"`
 	Name        string `gorm:"size:100;not null"`
 	Description string
 	Type        OrganizationType `gorm:"type:organization_type"`
-	CreatedAt   time.Time        `gorm:"autoCreateTime"`
-	UpdatedAt   time.Time        `gorm:"autoUpdateTime"`
+	// Правило кворума по умолчанию для новых тендеров: число голосов для FIXED, процент для PERCENT
+	QuorumRule  QuorumRule `gorm:"type:varchar(16);not null;default:'FIXED'"`
+	QuorumValue int        `gorm:"not null;default:3"`
+	// Сколько других ответственных должны согласовать тендер перед публикацией, 0 — без согласования
+	SignOffRequired int       `gorm:"not null;default:0"`
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
 }
 
 func (Organization) TableName() string {
@@ -2,12 +2,22 @@ package models
 
 import "time"
 
+type ResponsibleRole string
+
+const (
+	OWNER               ResponsibleRole = "OWNER"
+	PROCUREMENT_MANAGER ResponsibleRole = "PROCUREMENT_MANAGER"
+	APPROVER            ResponsibleRole = "APPROVER"
+	VIEWER              ResponsibleRole = "VIEWER"
+)
+
 type OrganizationResponsible struct {
-	ID             uint      `gorm:"primaryKey"`
-	OrganizationID uint      `gorm:"not null"`
-	UserID         uint      `gorm:"not null"`
-	CreatedAt      time.Time `gorm:"autoCreateTime"`
-	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+	ID             uint            `gorm:"primaryKey"`
+	OrganizationID uint            `gorm:"not null"`
+	UserID         uint            `gorm:"not null"`
+	Role           ResponsibleRole `gorm:"type:varchar(32);not null;default:'OWNER'"`
+	CreatedAt      time.Time       `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time       `gorm:"autoUpdateTime"`
 }
 
 func (OrganizationResponsible) TableName() string {
@@ -0,0 +1,12 @@
+package models
+
+// ServiceCategory категория услуг из иерархического справочника (коды в стиле ОКПД2)
+type ServiceCategory struct {
+	Code       string  `gorm:"primaryKey;type:varchar(32)" json:"code"`
+	Name       string  `gorm:"not null" json:"name"`
+	ParentCode *string `gorm:"type:varchar(32);index" json:"parentCode"`
+}
+
+func (ServiceCategory) TableName() string {
+	return "service_categories"
+}
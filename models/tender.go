@@ -2,6 +2,8 @@ package models
 
 import (
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type TenderStatus string
@@ -10,19 +12,73 @@ const (
 	CREATED   TenderStatus = "CREATED"
 	PUBLISHED TenderStatus = "PUBLISHED"
 	CLOSED    TenderStatus = "CLOSED"
+	CANCELLED TenderStatus = "CANCELLED"
+)
+
+// TenderVisibility кто видит тендер и может подавать на него предложения
+type TenderVisibility string
+
+const (
+	PUBLICVisibility     TenderVisibility = "PUBLIC"
+	INVITEONLYVisibility TenderVisibility = "INVITE_ONLY"
+)
+
+// TenderOutcome итог завершенного тендера для отчетности
+type TenderOutcome string
+
+const (
+	AWARDEDOutcome       TenderOutcome = "AWARDED"
+	NOTAWARDEDOutcome    TenderOutcome = "NOT_AWARDED"
+	EXPIREDOutcome       TenderOutcome = "EXPIRED"
+	EXPIREDNoBidsOutcome TenderOutcome = "EXPIRED_WITHOUT_BIDS"
+	CANCELLEDOutcome     TenderOutcome = "CANCELLED"
+)
+
+type TenderType string
+
+const (
+	STANDARD TenderType = "STANDARD"
+	AUCTION  TenderType = "AUCTION"
 )
 
 type Tender struct {
-	ID              uint   `gorm:"primaryKey"`
-	Name            string `gorm:"not null"`
-	Description     string
-	ServiceType     string
-	Status          TenderStatus `gorm:"type:tender_status;default:'CREATED'"`
-	OrganizationID  uint         `gorm:"not null"`
-	CreatorUsername string       `gorm:"not null"`
-	CreatedAt       time.Time    `gorm:"autoCreateTime"`
-	UpdatedAt       time.Time    `gorm:"autoUpdateTime"`
-	Version         int          `gorm:"default:1"`
+	ID                 uint   `gorm:"primaryKey"`
+	Name               string `gorm:"not null"`
+	Description        string
+	ServiceType        string
+	Status             TenderStatus        `gorm:"type:tender_status;default:'CREATED'"`
+	OrganizationID     uint                `gorm:"not null"`
+	CreatorUsername    string              `gorm:"not null"`
+	SubmissionDeadline *time.Time          `gorm:"index"`
+	PublishAt          *time.Time          `gorm:"index"`
+	Budget             decimal.NullDecimal `gorm:"type:numeric(20,2)" swaggertype:"string"`
+	Currency           string              `gorm:"type:varchar(3)"`
+	Sealed             bool                `gorm:"not null;default:false"`
+	Visibility         TenderVisibility    `gorm:"type:varchar(16);not null;default:'PUBLIC'"`
+	CancellationReason string
+	Outcome            TenderOutcome `gorm:"type:varchar(32)"`
+	Type               TenderType    `gorm:"type:varchar(16);not null;default:'STANDARD'"`
+	// Сколько предложений может победить, тендер закрывается при достижении этого числа
+	MaxWinners int `gorm:"not null;default:1"`
+	// Согласование перед публикацией: статус, номер раунда, сколько одобрений нужно и кто отправил
+	ApprovalStatus      ApprovalStatus `gorm:"type:varchar(16)"`
+	ApprovalRound       int
+	ApprovalsRequired   int
+	ApprovalSubmittedBy *uint
+	// Правило кворума тендера, при создании берется из организации, если не задано явно
+	QuorumRule  QuorumRule `gorm:"type:varchar(16)"`
+	QuorumValue int
+	// Параметры аукциона на понижение: шаг цены, длительность раунда и окно продления при позднем предложении
+	AuctionStep             decimal.NullDecimal `gorm:"type:numeric(20,2)" swaggertype:"string"`
+	AuctionRoundSeconds     int
+	AuctionExtensionSeconds int
+	// Текущее состояние аукциона, заполняется сервером
+	AuctionRound       int
+	AuctionRoundBids   int
+	AuctionRoundEndsAt *time.Time `gorm:"index"`
+	CreatedAt          time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time  `gorm:"autoUpdateTime"`
+	Version            int        `gorm:"default:1"`
 }
 
 func (Tender) TableName() string {
@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// TenderAmendment официальное изменение условий опубликованного тендера
+type TenderAmendment struct {
+	ID            uint      `gorm:"primaryKey" json:"id"`
+	TenderID      uint      `gorm:"not null;index" json:"tenderId"`
+	Number        int       `gorm:"not null" json:"number"`
+	TenderVersion int       `gorm:"not null" json:"tender_version"`
+	Note          string    `gorm:"type:text;not null" json:"note"`
+	ChangedFields string    `json:"changed_fields"`
+	AuthorID      uint      `gorm:"not null" json:"authorId"`
+	CreatedAt     time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (TenderAmendment) TableName() string {
+	return "tender_amendments"
+}
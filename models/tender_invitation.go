@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// TenderInvitation приглашение организации или сотрудника к участию в закрытом по приглашениям тендере
+type TenderInvitation struct {
+	ID             uint      `gorm:"primaryKey" json:"id"`
+	TenderID       uint      `gorm:"not null;index" json:"tenderId"`
+	OrganizationID *uint     `gorm:"index" json:"organizationId,omitempty"`
+	EmployeeID     *uint     `gorm:"index" json:"employeeId,omitempty"`
+	CreatedAt      time.Time `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (TenderInvitation) TableName() string {
+	return "tender_invitations"
+}
@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// TenderQuestion вопрос участника по условиям тендера и ответ организатора
+type TenderQuestion struct {
+	ID         uint   `gorm:"primaryKey"`
+	TenderID   uint   `gorm:"not null;index"`
+	AskerID    uint   `gorm:"not null"`
+	Question   string `gorm:"type:text;not null"`
+	Answer     string `gorm:"type:text"`
+	AnsweredBy *uint
+	AnsweredAt *time.Time
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time `gorm:"autoUpdateTime"`
+}
+
+func (TenderQuestion) TableName() string {
+	return "tender_questions"
+}
+
+// QuestionView вопрос в том виде, в котором его видит запросивший: автор вопроса виден только организаторам
+type QuestionView struct {
+	ID         uint       `json:"id"`
+	TenderID   uint       `json:"tenderId"`
+	Question   string     `json:"question"`
+	Answer     string     `json:"answer,omitempty"`
+	Answered   bool       `json:"answered"`
+	AnsweredAt *time.Time `json:"answered_at,omitempty"`
+	AskerID    *uint      `json:"askerId,omitempty"`
+	Own        bool       `json:"own"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
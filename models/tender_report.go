@@ -0,0 +1,14 @@
+package models
+
+// TenderReport количество тендеров организации по статусам и итогам
+type TenderReport struct {
+	OrganizationID     uint  `json:"organizationId"`
+	Total              int64 `json:"total"`
+	Created            int64 `json:"created"`
+	Published          int64 `json:"published"`
+	Awarded            int64 `json:"awarded"`
+	NotAwarded         int64 `json:"not_awarded"`
+	Expired            int64 `json:"expired"`
+	ExpiredWithoutBids int64 `json:"expired_without_bids"`
+	Cancelled          int64 `json:"cancelled"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "time"
+
+// ApprovalStatus состояние согласования тендера перед публикацией
+type ApprovalStatus string
+
+const (
+	PENDINGApproval  ApprovalStatus = "PENDING"
+	APPROVEDApproval ApprovalStatus = "APPROVED"
+	REJECTEDApproval ApprovalStatus = "REJECTED"
+)
+
+type SignOffDecision string
+
+const (
+	APPROVESignOff SignOffDecision = "APPROVE"
+	REJECTSignOff  SignOffDecision = "REJECT"
+)
+
+// TenderSignOff решение ответственного в раунде согласования тендера
+type TenderSignOff struct {
+	ID         uint            `gorm:"primaryKey" json:"id"`
+	TenderID   uint            `gorm:"not null;uniqueIndex:idx_tender_sign_off" json:"tenderId"`
+	Round      int             `gorm:"not null;uniqueIndex:idx_tender_sign_off" json:"round"`
+	ReviewerID uint            `gorm:"not null;uniqueIndex:idx_tender_sign_off" json:"reviewerId"`
+	Decision   SignOffDecision `gorm:"type:varchar(16);not null" json:"decision"`
+	Comment    string          `json:"comment"`
+	CreatedAt  time.Time       `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (TenderSignOff) TableName() string {
+	return "tender_sign_offs"
+}
+
+// TenderApproval текущее состояние согласования тендера и история решений по всем раундам
+type TenderApproval struct {
+	TenderID    uint            `json:"tenderId"`
+	Status      ApprovalStatus  `json:"status"`
+	Round       int             `json:"round"`
+	Required    int             `json:"required"`
+	Approvals   int64           `json:"approvals"`
+	SubmittedBy *uint           `json:"submittedBy"`
+	SignOffs    []TenderSignOff `json:"signOffs"`
+}
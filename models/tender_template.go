@@ -0,0 +1,58 @@
+package models
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// TenderTemplate шаблон организации для повторяющихся тендеров: настройки тендера без сроков, статуса и истории
+type TenderTemplate struct {
+	ID              uint   `gorm:"primaryKey" json:"id"`
+	OrganizationID  uint   `gorm:"not null;index" json:"organizationId"`
+	Title           string `gorm:"not null" json:"title"`
+	CreatorUsername string `gorm:"not null" json:"creatorUsername"`
+	// Настройки создаваемого тендера
+	Name                    string               `gorm:"not null" json:"name"`
+	Description             string               `json:"description"`
+	ServiceType             string               `json:"serviceType"`
+	Budget                  decimal.NullDecimal  `gorm:"type:numeric(20,2)" json:"budget" swaggertype:"string"`
+	Currency                string               `gorm:"type:varchar(3)" json:"currency"`
+	Sealed                  bool                 `gorm:"not null;default:false" json:"sealed"`
+	Visibility              TenderVisibility     `gorm:"type:varchar(16);not null;default:'PUBLIC'" json:"visibility"`
+	Type                    TenderType           `gorm:"type:varchar(16);not null;default:'STANDARD'" json:"type"`
+	AuctionStep             decimal.NullDecimal  `gorm:"type:numeric(20,2)" json:"auctionStep" swaggertype:"string"`
+	AuctionRoundSeconds     int                  `json:"auctionRoundSeconds"`
+	AuctionExtensionSeconds int                  `json:"auctionExtensionSeconds"`
+	MaxWinners              int                  `gorm:"not null;default:1" json:"maxWinners"`
+	QuorumRule              QuorumRule           `gorm:"type:varchar(16)" json:"quorumRule"`
+	QuorumValue             int                  `json:"quorumValue"`
+	Lots                    []TemplateLot        `gorm:"serializer:json" json:"lots"`
+	Criteria                []TemplateCriterion  `gorm:"serializer:json" json:"criteria"`
+	Invitations             []TemplateInvitation `gorm:"serializer:json" json:"invitations"`
+	CreatedAt               time.Time            `gorm:"autoCreateTime" json:"created_at"`
+}
+
+func (TenderTemplate) TableName() string {
+	return "tender_templates"
+}
+
+// TemplateLot лот, который будет создан в тендере по шаблону
+type TemplateLot struct {
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Budget      decimal.NullDecimal `json:"budget" swaggertype:"string"`
+}
+
+// TemplateCriterion критерий оценки, который будет создан в тендере по шаблону
+type TemplateCriterion struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Weight      decimal.Decimal `json:"weight" swaggertype:"string"`
+}
+
+// TemplateInvitation приглашение, которое будет создано в тендере по шаблону
+type TemplateInvitation struct {
+	OrganizationID *uint `json:"organizationId,omitempty"`
+	EmployeeID     *uint `json:"employeeId,omitempty"`
+}
@@ -1,16 +1,28 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type TenderVersion struct {
-	ID          uint   `gorm:"primaryKey"`
-	TenderID    uint   `gorm:"not null"`
-	Name        string `gorm:"not null"`
-	Description string
-	ServiceType string
-	Status      TenderStatus
-	Version     int       `gorm:"not null"`
-	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	ID                 uint   `gorm:"primaryKey"`
+	TenderID           uint   `gorm:"not null"`
+	Name               string `gorm:"not null"`
+	Description        string
+	ServiceType        string
+	Status             TenderStatus
+	SubmissionDeadline *time.Time
+	Budget             decimal.NullDecimal `gorm:"type:numeric(20,2)" swaggertype:"string"`
+	Currency           string              `gorm:"type:varchar(3)"`
+	CancellationReason string
+	QuorumRule         QuorumRule `gorm:"type:varchar(16)"`
+	QuorumValue        int
+	Version            int `gorm:"not null"`
+	// Сотрудник, внесший изменение; пусто для изменений, сделанных системой
+	ChangedBy *uint
+	CreatedAt time.Time `gorm:"autoCreateTime"`
 }
 
 func (TenderVersion) TableName() string {
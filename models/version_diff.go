@@ -0,0 +1,15 @@
+package models
+
+// VersionChange изменение одного поля между двумя версиями
+type VersionChange struct {
+	Field string      `json:"field"`
+	From  interface{} `json:"from"`
+	To    interface{} `json:"to"`
+}
+
+// VersionDiff поле за полем разница между двумя версиями тендера или предложения
+type VersionDiff struct {
+	FromVersion int             `json:"fromVersion"`
+	ToVersion   int             `json:"toVersion"`
+	Changes     []VersionChange `json:"changes"`
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"log"
 	"net/http"
+	"testAvito/models"
 )
 
 func JSONFormat(w http.ResponseWriter, r *http.Request, v interface{}) {
@@ -14,6 +15,18 @@ func JSONFormat(w http.ResponseWriter, r *http.Request, v interface{}) {
 		return
 	}
 
+	// Тендер и предложение отдаются с ETag версии для условных запросов If-Match
+	switch value := v.(type) {
+	case models.Tender:
+		w.Header().Set("ETag", VersionETag(value.Version))
+	case *models.Tender:
+		w.Header().Set("ETag", VersionETag(value.Version))
+	case models.Bid:
+		w.Header().Set("ETag", VersionETag(value.Version))
+	case *models.Bid:
+		w.Header().Set("ETag", VersionETag(value.Version))
+	}
+
 	w.WriteHeader(http.StatusOK)
 	_, err = w.Write(formattedJSON)
 	if err != nil {
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testAvito/models"
+
+	"gorm.io/gorm/clause"
+)
+
+// LoadServiceCategories загружает справочник категорий услуг из JSON-файла.
+// Существующие коды обновляются, коды, отсутствующие в файле, остаются в базе.
+func LoadServiceCategories(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var categories []models.ServiceCategory
+	if err := json.Unmarshal(data, &categories); err != nil {
+		return fmt.Errorf("неверный формат справочника категорий: %w", err)
+	}
+	if len(categories) == 0 {
+		return nil
+	}
+
+	// Родитель должен быть описан в файле раньше потомка или уже быть в базе
+	known := make(map[string]bool, len(categories))
+	for _, category := range categories {
+		if category.Code == "" || category.Name == "" {
+			return fmt.Errorf("у категории должны быть заданы code и name")
+		}
+		if category.ParentCode != nil && !known[*category.ParentCode] {
+			var count int64
+			if err := DB.Model(&models.ServiceCategory{}).Where("code = ?", *category.ParentCode).Count(&count).Error; err != nil {
+				return err
+			}
+			if count == 0 {
+				return fmt.Errorf("неизвестная родительская категория %s у категории %s", *category.ParentCode, category.Code)
+			}
+		}
+		known[category.Code] = true
+	}
+
+	return DB.Clauses(clause.OnConflict{UpdateAll: true}).Create(&categories).Error
+}
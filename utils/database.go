@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"testAvito/models"
 
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -12,7 +11,21 @@ import (
 
 var DB *gorm.DB
 
+// InitDB подключается к базе и применяет непримененные миграции схемы
 func InitDB() {
+	ConnectDB()
+
+	applied, err := MigrateUp()
+	if err != nil {
+		log.Fatalf("Ошибка миграции базы данных: %v", err)
+	}
+	if applied > 0 {
+		log.Printf("Применено миграций базы данных: %d", applied)
+	}
+}
+
+// ConnectDB подключается к базе без применения миграций
+func ConnectDB() {
 	dsn := fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=disable",
 		os.Getenv("POSTGRES_HOST"),
@@ -23,23 +36,9 @@ func InitDB() {
 	)
 
 	var err error
-	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	// Ошибки нарушения уникальности и внешних ключей переводятся в gorm.ErrDuplicatedKey и gorm.ErrForeignKeyViolated
+	DB, err = gorm.Open(postgres.Open(dsn), &gorm.Config{TranslateError: true})
 	if err != nil {
 		log.Fatalf("Ошибка подключения к базе данных: %v", err)
 	}
-
-	if err = DB.AutoMigrate(
-		&models.Tender{},
-		&models.TenderVersion{},
-		&models.BidVersion{},
-		&models.Bid{},
-		&models.Employee{},
-		&models.BidFeedback{},
-		&models.BidDecision{},
-		&models.Organization{},
-	); err != nil {
-		log.Println("Ошибка миграции базы данных", err.Error())
-		return
-	}
-
 }
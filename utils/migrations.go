@@ -0,0 +1,316 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testAvito/db"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Ключ advisory-блокировки, чтобы несколько экземпляров сервера не применяли миграции одновременно
+const migrationLockKey = 7340912
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Метка в начале файла миграции, которую нельзя выполнять в транзакции (например, ALTER TYPE ... ADD VALUE до PostgreSQL 12)
+const noTransactionMarker = "-- migrate:no-transaction"
+
+// Migration версия схемы с SQL для применения и отката
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationState состояние миграции в базе, AppliedAt пуст у непримененных
+type MigrationState struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time
+	// Миграция применена в базе, но ее нет в бинарнике
+	Missing bool
+}
+
+// Запись о примененной миграции
+type schemaMigration struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// LoadMigrations читает встроенные миграции, отсортированные по версии
+func LoadMigrations() ([]Migration, error) {
+	files, err := fs.ReadDir(db.Migrations, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, file := range files {
+		match := migrationFileName.FindStringSubmatch(file.Name())
+		if match == nil {
+			return nil, fmt.Errorf("неверное имя файла миграции %s", file.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("неверная версия миграции %s", file.Name())
+		}
+		body, err := fs.ReadFile(db.Migrations, "migrations/"+file.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("у миграции %d разные названия: %s и %s", version, migration.Name, match[2])
+		}
+		if match[3] == "up" {
+			migration.Up = string(body)
+		} else {
+			migration.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("у миграции %d нет файла up", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// MigrateUp применяет все непримененные миграции по порядку, каждую в своей транзакции, кроме помеченных noTransactionMarker.
+// Возвращает число примененных миграций
+func MigrateUp() (int, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	if err := createMigrationsTable(); err != nil {
+		return 0, err
+	}
+
+	applied := 0
+	for _, migration := range migrations {
+		if noTransaction(migration.Up) {
+			done, err := applyWithoutTransaction(migration)
+			if err != nil {
+				return applied, fmt.Errorf("миграция %06d_%s: %w", migration.Version, migration.Name, err)
+			}
+			if done {
+				applied++
+			}
+			continue
+		}
+
+		done := false
+		err := DB.Transaction(func(tx *gorm.DB) error {
+			if err := lockMigrations(tx); err != nil {
+				return err
+			}
+			// Миграцию мог применить другой экземпляр, пока мы ждали блокировку
+			var count int64
+			if err := tx.Model(&schemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+				return err
+			}
+			if count > 0 {
+				return nil
+			}
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			done = true
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+		})
+		if err != nil {
+			return applied, fmt.Errorf("миграция %06d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if done {
+			applied++
+		}
+	}
+	return applied, nil
+}
+
+// MigrateDown откатывает steps последних примененных миграций, каждую в своей транзакции, кроме помеченных noTransactionMarker.
+// Возвращает число откаченных миграций
+func MigrateDown(steps int) (int, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return 0, err
+	}
+	if err := createMigrationsTable(); err != nil {
+		return 0, err
+	}
+	byVersion := make(map[int64]Migration, len(migrations))
+	for _, migration := range migrations {
+		byVersion[migration.Version] = migration
+	}
+
+	reverted := 0
+	for reverted < steps {
+		done := false
+		// Сессионная блокировка держится на одном соединении и на время отката без транзакции
+		err := withMigrationLock(func(conn *gorm.DB) error {
+			var last schemaMigration
+			err := conn.Order("version DESC").First(&last).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+
+			migration, ok := byVersion[last.Version]
+			if !ok {
+				return fmt.Errorf("миграции %06d_%s нет в бинарнике", last.Version, last.Name)
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("у миграции %06d_%s нет файла down", last.Version, last.Name)
+			}
+
+			if noTransaction(migration.Down) {
+				if err := execWithoutTransaction(conn, migration.Down); err != nil {
+					return fmt.Errorf("миграция %06d_%s: %w", last.Version, last.Name, err)
+				}
+				done = true
+				return conn.Where("version = ?", last.Version).Delete(&schemaMigration{}).Error
+			}
+			return conn.Transaction(func(tx *gorm.DB) error {
+				if err := tx.Exec(migration.Down).Error; err != nil {
+					return fmt.Errorf("миграция %06d_%s: %w", last.Version, last.Name, err)
+				}
+				done = true
+				return tx.Where("version = ?", last.Version).Delete(&schemaMigration{}).Error
+			})
+		})
+		if err != nil {
+			return reverted, err
+		}
+		if !done {
+			break
+		}
+		reverted++
+	}
+	return reverted, nil
+}
+
+// Применяет миграцию с меткой noTransactionMarker. Вместо транзакционной блокировки берется сессионная
+// на выделенном соединении, поэтому другой экземпляр не применит ту же миграцию параллельно.
+// Если SQL выполнился, а запись о миграции не сохранилась, повторный запуск выполнит его снова,
+// поэтому такие миграции должны быть идемпотентными (IF NOT EXISTS)
+func applyWithoutTransaction(migration Migration) (bool, error) {
+	done := false
+	err := withMigrationLock(func(conn *gorm.DB) error {
+		var count int64
+		if err := conn.Model(&schemaMigration{}).Where("version = ?", migration.Version).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		if err := execWithoutTransaction(conn, migration.Up); err != nil {
+			return err
+		}
+		done = true
+		return conn.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now()}).Error
+	})
+	return done, err
+}
+
+// MigrationStatus возвращает состояние всех встроенных миграций и примененных миграций, которых нет в бинарнике
+func MigrationStatus() ([]MigrationState, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+	if err := createMigrationsTable(); err != nil {
+		return nil, err
+	}
+	var applied []schemaMigration
+	if err := DB.Order("version").Find(&applied).Error; err != nil {
+		return nil, err
+	}
+	appliedAt := make(map[int64]time.Time, len(applied))
+	for _, row := range applied {
+		appliedAt[row.Version] = row.AppliedAt
+	}
+
+	known := make(map[int64]bool, len(migrations))
+	states := make([]MigrationState, 0, len(migrations))
+	for _, migration := range migrations {
+		known[migration.Version] = true
+		state := MigrationState{Version: migration.Version, Name: migration.Name}
+		if at, ok := appliedAt[migration.Version]; ok {
+			state.AppliedAt = &at
+		}
+		states = append(states, state)
+	}
+	for _, row := range applied {
+		if !known[row.Version] {
+			at := row.AppliedAt
+			states = append(states, MigrationState{Version: row.Version, Name: row.Name, AppliedAt: &at, Missing: true})
+		}
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Version < states[j].Version })
+	return states, nil
+}
+
+func createMigrationsTable() error {
+	return DB.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version    BIGINT PRIMARY KEY,
+		name       TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`).Error
+}
+
+// Блокировка держится до конца транзакции
+func lockMigrations(tx *gorm.DB) error {
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockKey).Error
+}
+
+// Выполняет fn на одном соединении пула под сессионной блокировкой с тем же ключом, что и lockMigrations
+func withMigrationLock(fn func(conn *gorm.DB) error) error {
+	return DB.Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(?)", migrationLockKey).Error; err != nil {
+			return err
+		}
+		defer conn.Exec("SELECT pg_advisory_unlock(?)", migrationLockKey)
+		return fn(conn)
+	})
+}
+
+// Выполняет SQL без транзакции. Файл, в котором кроме комментариев ничего нет, пропускается
+func execWithoutTransaction(conn *gorm.DB, sql string) error {
+	for _, line := range strings.Split(sql, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "--") {
+			return conn.Exec(sql).Error
+		}
+	}
+	return nil
+}
+
+// Файл миграции начинается с метки noTransactionMarker
+func noTransaction(sql string) bool {
+	return strings.HasPrefix(strings.TrimSpace(sql), noTransactionMarker)
+}
@@ -0,0 +1,77 @@
+package utils
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Время жизни токена по умолчанию, если JWT_TTL не задан
+const defaultTokenTTL = 24 * time.Hour
+
+// Claims хранит данные сотрудника внутри токена
+type Claims struct {
+	EmployeeID uint   `json:"employee_id"`
+	Username   string `json:"username"`
+	jwt.RegisteredClaims
+}
+
+func tokenSecret() ([]byte, error) {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return nil, errors.New("JWT_SECRET не задан")
+	}
+	return []byte(secret), nil
+}
+
+func tokenTTL() time.Duration {
+	if ttl, err := time.ParseDuration(os.Getenv("JWT_TTL")); err == nil && ttl > 0 {
+		return ttl
+	}
+	return defaultTokenTTL
+}
+
+// GenerateToken выпускает подписанный токен для сотрудника и возвращает его вместе со временем истечения
+func GenerateToken(employeeID uint, username string) (string, time.Time, error) {
+	secret, err := tokenSecret()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	expiresAt := time.Now().Add(tokenTTL())
+	claims := Claims{
+		EmployeeID: employeeID,
+		Username:   username,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   strconv.FormatUint(uint64(employeeID), 10),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
+}
+
+// ParseToken проверяет подпись и срок действия токена и возвращает его данные
+func ParseToken(tokenString string) (*Claims, error) {
+	secret, err := tokenSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	_, err = jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+	return &claims, nil
+}
@@ -2,13 +2,31 @@ package validators
 
 import (
 	"errors"
+	"fmt"
 	"gorm.io/gorm"
 	"log"
 	"net/http"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testAvito/models"
 	"testAvito/utils"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Валюта по умолчанию для тендеров с бюджетом
+const DefaultCurrency = "RUB"
+
+// Правило кворума по умолчанию: min(3, количество ответственных с правом голоса)
+const (
+	DefaultQuorumRule  = models.FIXEDQuorum
+	DefaultQuorumValue = 3
 )
 
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
 // Проверка корректности введеного имени пользователя
 func CheckUsername(employee *models.Employee) (bool, error) {
 	if employee.Username == "" {
@@ -52,8 +70,10 @@ func CheckCorrectStatusTender(status models.TenderStatus) error {
 		return nil
 	case models.CLOSED:
 		return nil
+	case models.CANCELLED:
+		return nil
 	default:
-		return errors.New("Неверный статус, статус должен быть: PUBLISHED, CREATED, CLOSED")
+		return errors.New("Неверный статус, статус должен быть: PUBLISHED, CREATED, CLOSED, CANCELLED")
 	}
 }
 
@@ -125,15 +145,445 @@ func ValidateCreateTender(w http.ResponseWriter, tender *models.Tender) error {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return err
 	}
-	responsible, err := CheckOrganizationResponsible(tender.OrganizationID, employee.ID)
-	if err != nil {
+	if err = CheckPermission(tender.OrganizationID, employee.ID, ActionCreateTender); err != nil {
 		log.Println(err)
+		if errors.Is(err, ErrNoPermission) {
+			http.Error(w, "У вас нет прав создавать тендеры организации", http.StatusForbidden)
+			return err
+		}
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return err
 	}
-	if !responsible {
-		http.Error(w, "User is not responsible for the organizations", http.StatusBadRequest)
+	return nil
+}
+
+// Проверка типа организации
+func CheckCorrectOrganizationType(orgType models.OrganizationType) error {
+	switch orgType {
+	case models.IE, models.LLC, models.JSC:
+		return nil
+	default:
+		return errors.New("Неверный тип организации, тип должен быть: IE, LLC, JSC")
+	}
+}
+
+// Проверка, что имя пользователя еще не занято другим сотрудником
+func CheckUsernameFree(username string, exceptID uint) error {
+	if username == "" {
+		return errors.New("Пользователь не введен")
+	}
+	var count int64
+	if err := utils.DB.Model(&models.Employee{}).Where("username = ? AND id <> ?", username, exceptID).Count(&count).Error; err != nil {
+		return errors.New("Ошибка базы данных")
+	}
+	if count > 0 {
+		return errors.New("Пользователь с таким именем уже существует")
+	}
+	return nil
+}
+
+// Проверка данных организации при создании и редактировании
+func ValidateOrganization(organization *models.Organization) error {
+	if organization.Name == "" {
+		return errors.New("Название организации не введено")
+	}
+	if len([]rune(organization.Name)) > 100 {
+		return errors.New("Название организации не должно превышать 100 символов")
+	}
+	if err := CheckQuorumRule(&organization.QuorumRule, &organization.QuorumValue); err != nil {
+		return err
+	}
+	if organization.SignOffRequired < 0 || organization.SignOffRequired > 20 {
+		return errors.New("Число согласующих signOffRequired должно быть от 0 до 20")
+	}
+	return CheckCorrectOrganizationType(organization.Type)
+}
+
+// Проверка срока подачи предложений: если он задан, то должен быть в будущем
+func CheckSubmissionDeadline(deadline *time.Time) error {
+	if deadline != nil && !deadline.After(time.Now()) {
+		return errors.New("Срок подачи предложений должен быть в будущем")
+	}
+	return nil
+}
+
+// Проверка времени запланированной публикации: в будущем и раньше срока подачи предложений
+func CheckPublishAt(publishAt time.Time, deadline *time.Time) error {
+	if !publishAt.After(time.Now()) {
+		return errors.New("Время публикации должно быть в будущем")
+	}
+	if deadline != nil && !publishAt.Before(*deadline) {
+		return errors.New("Время публикации должно быть раньше срока подачи предложений")
+	}
+	return nil
+}
+
+// Проверка денежной суммы: положительная и не больше двух знаков после запятой
+func CheckAmount(amount decimal.NullDecimal) error {
+	if !amount.Valid {
+		return nil
+	}
+	if !amount.Decimal.IsPositive() {
+		return errors.New("Сумма должна быть больше нуля")
+	}
+	if !amount.Decimal.Equal(amount.Decimal.Truncate(2)) {
+		return errors.New("Сумма не может содержать больше двух знаков после запятой")
+	}
+	return nil
+}
+
+// Проверка кода валюты в формате ISO 4217
+func CheckCurrency(currency string) error {
+	if !currencyPattern.MatchString(currency) {
+		return errors.New("Неверная валюта, ожидается трехбуквенный код ISO 4217, например RUB")
+	}
+	return nil
+}
+
+// Проверка бюджета тендера и его валюты, при пустой валюте подставляется валюта по умолчанию
+func ValidateTenderBudget(tender *models.Tender) error {
+	if err := CheckAmount(tender.Budget); err != nil {
+		return err
+	}
+	if tender.Currency == "" {
+		tender.Currency = DefaultCurrency
+	}
+	return CheckCurrency(tender.Currency)
+}
+
+// Проверка суммы предложения относительно максимальной цены тендера
+func CheckBidAmount(amount decimal.NullDecimal, tender models.Tender) error {
+	if err := CheckAmount(amount); err != nil {
+		return err
+	}
+	if amount.Valid && tender.Budget.Valid && amount.Decimal.GreaterThan(tender.Budget.Decimal) {
+		return errors.New("Сумма предложения превышает максимальную цену тендера " + tender.Budget.Decimal.StringFixed(2) + " " + tender.Currency)
+	}
+	return nil
+}
+
+// Закрытому (sealed) тендеру нужен срок подачи предложений, иначе предложения никогда не вскроются
+func CheckSealed(tender *models.Tender) error {
+	if tender.Sealed && tender.SubmissionDeadline == nil {
+		return errors.New("Для закрытого (sealed) тендера необходимо указать срок подачи предложений")
+	}
+	return nil
+}
+
+// Проверка типа тендера и параметров аукциона на понижение, при пустом типе подставляется STANDARD
+func ValidateAuction(tender *models.Tender) error {
+	if tender.Type == "" {
+		tender.Type = models.STANDARD
+	}
+	switch tender.Type {
+	case models.STANDARD:
+		if tender.AuctionStep.Valid || tender.AuctionRoundSeconds != 0 || tender.AuctionExtensionSeconds != 0 {
+			return errors.New("Параметры аукциона задаются только для тендера типа AUCTION")
+		}
+		return nil
+	case models.AUCTION:
+	default:
+		return errors.New("Неверный тип тендера, тип должен быть: STANDARD, AUCTION")
+	}
+
+	if !tender.AuctionStep.Valid {
+		return errors.New("Для аукциона необходимо указать шаг цены AuctionStep")
+	}
+	if err := CheckAmount(tender.AuctionStep); err != nil {
+		return errors.New("Неверный шаг цены аукциона: " + err.Error())
+	}
+	if tender.AuctionRoundSeconds <= 0 {
+		return errors.New("Длительность раунда аукциона AuctionRoundSeconds должна быть больше нуля")
+	}
+	if tender.AuctionExtensionSeconds < 0 || tender.AuctionExtensionSeconds > tender.AuctionRoundSeconds {
+		return errors.New("Окно продления AuctionExtensionSeconds должно быть от нуля до длительности раунда")
+	}
+	// Аукцион идет открыто и завершается по раундам, а не по сроку подачи
+	if tender.Sealed {
+		return errors.New("Аукцион не может проводиться в режиме закрытых предложений")
+	}
+	if tender.SubmissionDeadline != nil {
+		return errors.New("Для аукциона срок подачи предложений не задается, он завершается по окончании раундов")
+	}
+	if tender.ID != 0 {
+		var lots int64
+		if err := utils.DB.Model(&models.Lot{}).Where("tender_id = ?", tender.ID).Count(&lots).Error; err != nil {
+			return errors.New("Ошибка базы данных")
+		}
+		if lots > 0 {
+			return errors.New("Аукцион не может проводиться по тендеру с лотами")
+		}
+	}
+	return nil
+}
+
+// Проверка нового снижения цены в аукционе: сумма ниже текущей лучшей цены как минимум на шаг аукциона
+func CheckAuctionPrice(amount decimal.Decimal, best decimal.NullDecimal, tender models.Tender) error {
+	if err := CheckBidAmount(decimal.NewNullDecimal(amount), tender); err != nil {
+		return err
+	}
+	if best.Valid && amount.GreaterThan(best.Decimal.Sub(tender.AuctionStep.Decimal)) {
+		return errors.New("Цена должна быть не больше " + best.Decimal.Sub(tender.AuctionStep.Decimal).StringFixed(2) + " " + tender.Currency + " (текущая лучшая цена минус шаг аукциона)")
+	}
+	return nil
+}
+
+// Проверка данных лота при создании и редактировании
+func ValidateLot(lot *models.Lot) error {
+	if lot.Name == "" {
+		return errors.New("Название лота не введено")
+	}
+	return CheckAmount(lot.Budget)
+}
+
+// Проверка суммы предложения относительно максимальной цены лота, а без нее — тендера
+func CheckLotBidAmount(amount decimal.NullDecimal, lot *models.Lot, tender models.Tender) error {
+	if lot == nil || !lot.Budget.Valid {
+		return CheckBidAmount(amount, tender)
+	}
+	if err := CheckAmount(amount); err != nil {
+		return err
+	}
+	if amount.Valid && amount.Decimal.GreaterThan(lot.Budget.Decimal) {
+		return errors.New("Сумма предложения превышает максимальную цену лота " + lot.Budget.Decimal.StringFixed(2) + " " + tender.Currency)
+	}
+	return nil
+}
+
+// Проверка критерия оценки: название и положительный вес не больше двух знаков после запятой
+func ValidateCriterion(criterion *models.EvaluationCriterion) error {
+	if criterion.Name == "" {
+		return errors.New("Название критерия не введено")
+	}
+	if !criterion.Weight.IsPositive() {
+		return errors.New("Вес критерия должен быть больше нуля")
+	}
+	if !criterion.Weight.Equal(criterion.Weight.Truncate(2)) {
+		return errors.New("Вес критерия не может содержать больше двух знаков после запятой")
+	}
+	return nil
+}
+
+// Проверка оценки по критерию
+func CheckCriterionScore(score int) error {
+	if score < 0 || score > models.MaxCriterionScore {
+		return fmt.Errorf("Оценка должна быть от 0 до %d", models.MaxCriterionScore)
+	}
+	return nil
+}
+
+// Проверка причины отмены тендера: обязательна и не длиннее 1000 символов
+func CheckCancellationReason(reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return errors.New("Необходимо указать причину отмены тендера reason")
+	}
+	if len([]rune(reason)) > 1000 {
+		return errors.New("Причина отмены не должна превышать 1000 символов")
+	}
+	return nil
+}
+
+// Проверка текста вопроса или ответа по тендеру
+func CheckQuestionText(text string) error {
+	if strings.TrimSpace(text) == "" {
+		return errors.New("Текст не может быть пустым")
+	}
+	if len([]rune(text)) > 2000 {
+		return errors.New("Текст не должен превышать 2000 символов")
+	}
+	return nil
+}
+
+// Проверка пояснения к изменению условий опубликованного тендера
+func CheckChangeNote(note string) error {
+	if strings.TrimSpace(note) == "" {
+		return errors.New("Изменение опубликованного тендера оформляется официально, укажите пояснение changeNote")
+	}
+	if len([]rune(note)) > 2000 {
+		return errors.New("Пояснение к изменению не должно превышать 2000 символов")
+	}
+	return nil
+}
+
+// Проверка режима видимости тендера, при пустом значении тендер публичный
+func CheckVisibility(tender *models.Tender) error {
+	switch tender.Visibility {
+	case "":
+		tender.Visibility = models.PUBLICVisibility
+		return nil
+	case models.PUBLICVisibility, models.INVITEONLYVisibility:
+		return nil
+	default:
+		return errors.New("Неверная видимость тендера, должна быть: PUBLIC, INVITE_ONLY")
+	}
+}
+
+// Проверка кода категории услуг по справочнику, пустое значение допускается
+func CheckServiceType(serviceType *string) error {
+	*serviceType = strings.TrimSpace(*serviceType)
+	if *serviceType == "" {
+		return nil
+	}
+	var count int64
+	if err := utils.DB.Model(&models.ServiceCategory{}).Where("code = ?", *serviceType).Count(&count).Error; err != nil {
 		return err
 	}
+	if count == 0 {
+		return fmt.Errorf("Неизвестный код категории услуг: %s", *serviceType)
+	}
+	return nil
+}
+
+// Допустимый тип вложения: MIME-тип по расширению и тип, который должен определяться по содержимому
+type attachmentType struct {
+	contentType string
+	// Пустое значение — формат по содержимому надежно не определяется
+	sniffed string
+}
+
+var attachmentTypes = map[string]attachmentType{
+	".pdf":  {"application/pdf", "application/pdf"},
+	".doc":  {"application/msword", ""},
+	".docx": {"application/vnd.openxmlformats-officedocument.wordprocessingml.document", "application/zip"},
+	".xls":  {"application/vnd.ms-excel", ""},
+	".xlsx": {"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", "application/zip"},
+	".odt":  {"application/vnd.oasis.opendocument.text", "application/zip"},
+	".ods":  {"application/vnd.oasis.opendocument.spreadsheet", "application/zip"},
+	".zip":  {"application/zip", "application/zip"},
+	".txt":  {"text/plain", "text/plain"},
+	".csv":  {"text/csv", "text/plain"},
+	".png":  {"image/png", "image/png"},
+	".jpg":  {"image/jpeg", "image/jpeg"},
+	".jpeg": {"image/jpeg", "image/jpeg"},
+}
+
+// Проверка типа вложения по расширению и началу содержимого, возвращает MIME-тип для скачивания
+func CheckAttachmentType(fileName string, sniffed string) (string, error) {
+	if strings.TrimSpace(fileName) == "" {
+		return "", errors.New("У файла должно быть имя")
+	}
+	allowed, ok := attachmentTypes[strings.ToLower(filepath.Ext(fileName))]
+	if !ok {
+		return "", errors.New("Недопустимый тип файла, разрешены: pdf, doc, docx, xls, xlsx, odt, ods, zip, txt, csv, png, jpg, jpeg")
+	}
+	// DetectContentType для текста добавляет кодировку: "text/plain; charset=utf-8"
+	if allowed.sniffed != "" && !strings.HasPrefix(sniffed, allowed.sniffed) {
+		return "", errors.New("Содержимое файла не соответствует его расширению")
+	}
+	return allowed.contentType, nil
+}
+
+// Проверка размера загруженного вложения
+func CheckAttachmentSize(size int64, maxSize int64) error {
+	if size == 0 {
+		return errors.New("Файл пустой")
+	}
+	if size > maxSize {
+		return fmt.Errorf("Размер файла не должен превышать %d байт", maxSize)
+	}
+	return nil
+}
+
+// Проверка правила кворума, при пустом правиле используется правило по умолчанию
+func CheckQuorumRule(rule *models.QuorumRule, value *int) error {
+	switch *rule {
+	case "":
+		*rule, *value = DefaultQuorumRule, DefaultQuorumValue
+	case models.FIXEDQuorum:
+		if *value < 1 {
+			return errors.New("Для кворума FIXED укажите число голосов quorumValue не меньше 1")
+		}
+	case models.PERCENTQuorum:
+		if *value < 1 || *value > 100 {
+			return errors.New("Для кворума PERCENT укажите процент quorumValue от 1 до 100")
+		}
+	case models.MAJORITYQuorum, models.UNANIMOUSQuorum:
+		*value = 0
+	default:
+		return errors.New("Неверное правило кворума, должно быть: FIXED, PERCENT, MAJORITY, UNANIMOUS")
+	}
+	return nil
+}
+
+// QuorumSize число утверждений, необходимое для принятия предложения при заданном числе голосующих
+func QuorumSize(rule models.QuorumRule, value int, voters int64) int64 {
+	var quorum int64
+	switch rule {
+	case models.PERCENTQuorum:
+		// Процент округляется вверх: 50% от 3 голосующих — 2 голоса
+		quorum = (voters*int64(value) + 99) / 100
+	case models.MAJORITYQuorum:
+		quorum = voters/2 + 1
+	case models.UNANIMOUSQuorum:
+		quorum = voters
+	default:
+		quorum = int64(value)
+	}
+	if quorum > voters {
+		quorum = voters
+	}
+	if quorum < 1 {
+		quorum = 1
+	}
+	return quorum
+}
+
+// Проверка числа победителей тендера, при нуле тендер присуждается одному предложению
+func CheckMaxWinners(tender *models.Tender) error {
+	if tender.MaxWinners == 0 {
+		tender.MaxWinners = 1
+	}
+	if tender.MaxWinners < 0 || tender.MaxWinners > 100 {
+		return errors.New("Число победителей MaxWinners должно быть от 1 до 100")
+	}
+	if tender.MaxWinners == 1 {
+		return nil
+	}
+	if tender.Type == models.AUCTION {
+		return errors.New("У аукциона может быть только один победитель")
+	}
+	if tender.ID != 0 {
+		var lots int64
+		if err := utils.DB.Model(&models.Lot{}).Where("tender_id = ?", tender.ID).Count(&lots).Error; err != nil {
+			return errors.New("Ошибка базы данных")
+		}
+		if lots > 0 {
+			return errors.New("В тендере с лотами каждый лот присуждается одному предложению")
+		}
+	}
+	return nil
+}
+
+// Проверка доли победителя: от 0 до 100 процентов, вместе с долями остальных победителей не больше 100
+func CheckAwardShare(share decimal.NullDecimal, awarded decimal.Decimal) error {
+	if !share.Valid {
+		return nil
+	}
+	if !share.Decimal.IsPositive() || share.Decimal.GreaterThan(decimal.NewFromInt(100)) {
+		return errors.New("Доля share должна быть больше 0 и не больше 100 процентов")
+	}
+	if !share.Decimal.Equal(share.Decimal.Truncate(2)) {
+		return errors.New("Доля share указывается не точнее сотых")
+	}
+	if total := awarded.Add(share.Decimal); total.GreaterThan(decimal.NewFromInt(100)) {
+		return fmt.Errorf("Сумма долей победителей не может превышать 100%%, уже распределено %s%%", awarded.String())
+	}
+	return nil
+}
+
+// Проверка решения по согласованию тендера: отказ обязательно сопровождается комментарием
+func CheckSignOff(decision models.SignOffDecision, comment string) error {
+	switch decision {
+	case models.APPROVESignOff:
+	case models.REJECTSignOff:
+		if strings.TrimSpace(comment) == "" {
+			return errors.New("При отклонении тендера укажите комментарий comment")
+		}
+	default:
+		return errors.New("Неверное решение, решение должно быть: APPROVE, REJECT")
+	}
+	if len([]rune(comment)) > 2000 {
+		return errors.New("Комментарий не должен превышать 2000 символов")
+	}
 	return nil
 }
@@ -0,0 +1,106 @@
+package validators
+
+import (
+	"errors"
+	"gorm.io/gorm"
+	"testAvito/models"
+	"testAvito/utils"
+)
+
+// Action действие внутри организации, для которого нужна определенная роль
+type Action string
+
+const (
+	ActionViewTender         Action = "view_tender"
+	ActionCreateTender       Action = "create_tender"
+	ActionEditTender         Action = "edit_tender"
+	ActionPublishTender      Action = "publish_tender"
+	ActionCloseTender        Action = "close_tender"
+	ActionRollbackTender     Action = "rollback_tender"
+	ActionViewBids           Action = "view_bids"
+	ActionVoteBid            Action = "vote_bid"
+	ActionWriteFeedback      Action = "write_feedback"
+	ActionReadReviews        Action = "read_reviews"
+	ActionAnswerQuestion     Action = "answer_question"
+	ActionApproveTender      Action = "approve_tender"
+	ActionManageBid          Action = "manage_bid"
+	ActionManageOrganization Action = "manage_organization"
+	ActionManageResponsibles Action = "manage_responsibles"
+)
+
+var (
+	ErrNoPermission      = errors.New("Недостаточно прав для выполнения действия")
+	ErrNotResponsible    = errors.New("Данный пользователь не ответственен за организацию")
+	ErrPermissionCheckDB = errors.New("Ошибка при проверке прав в бд")
+)
+
+// Роли, которым разрешено каждое действие. Владелец может все.
+var actionRoles = map[Action][]models.ResponsibleRole{
+	ActionViewTender:         {models.OWNER, models.PROCUREMENT_MANAGER, models.APPROVER, models.VIEWER},
+	ActionCreateTender:       {models.OWNER, models.PROCUREMENT_MANAGER},
+	ActionEditTender:         {models.OWNER, models.PROCUREMENT_MANAGER},
+	ActionPublishTender:      {models.OWNER, models.PROCUREMENT_MANAGER},
+	ActionCloseTender:        {models.OWNER, models.PROCUREMENT_MANAGER},
+	ActionRollbackTender:     {models.OWNER, models.PROCUREMENT_MANAGER},
+	ActionViewBids:           {models.OWNER, models.PROCUREMENT_MANAGER, models.APPROVER, models.VIEWER},
+	ActionVoteBid:            {models.OWNER, models.APPROVER},
+	ActionWriteFeedback:      {models.OWNER, models.PROCUREMENT_MANAGER, models.APPROVER},
+	ActionReadReviews:        {models.OWNER, models.PROCUREMENT_MANAGER, models.APPROVER},
+	ActionAnswerQuestion:     {models.OWNER, models.PROCUREMENT_MANAGER},
+	ActionApproveTender:      {models.OWNER, models.PROCUREMENT_MANAGER, models.APPROVER},
+	ActionManageBid:          {models.OWNER, models.PROCUREMENT_MANAGER},
+	ActionManageOrganization: {models.OWNER},
+	ActionManageResponsibles: {models.OWNER},
+}
+
+// RolesFor возвращает роли, которым разрешено действие
+func RolesFor(action Action) []models.ResponsibleRole {
+	return actionRoles[action]
+}
+
+// Проверка роли ответственного
+func CheckCorrectResponsibleRole(role models.ResponsibleRole) error {
+	switch role {
+	case models.OWNER, models.PROCUREMENT_MANAGER, models.APPROVER, models.VIEWER:
+		return nil
+	default:
+		return errors.New("Неверная роль, роль должна быть: OWNER, PROCUREMENT_MANAGER, APPROVER, VIEWER")
+	}
+}
+
+// CheckPermission проверяет, что сотрудник ответственен за организацию с ролью, допускающей действие
+func CheckPermission(orgId uint, employeeId uint, action Action) error {
+	var orgResponsible models.OrganizationResponsible
+	err := utils.DB.Where("organization_id = ? AND user_id = ?", orgId, employeeId).First(&orgResponsible).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrNotResponsible
+	}
+	if err != nil {
+		return ErrPermissionCheckDB
+	}
+
+	for _, role := range actionRoles[action] {
+		if orgResponsible.Role == role {
+			return nil
+		}
+	}
+	return ErrNoPermission
+}
+
+// CountResponsiblesWithPermission считает ответственных организации, которым разрешено действие
+func CountResponsiblesWithPermission(orgId uint, action Action) (int64, error) {
+	var count int64
+	err := utils.DB.Model(&models.OrganizationResponsible{}).
+		Where("organization_id = ? AND role IN ?", orgId, actionRoles[action]).
+		Count(&count).Error
+	return count, err
+}
+
+// IsOrganizationOwner проверяет, что сотрудник владеет хотя бы одной организацией
+func IsOrganizationOwner(employeeId uint) (bool, error) {
+	var count int64
+	err := utils.DB.Model(&models.OrganizationResponsible{}).
+		Where("user_id = ? AND role = ?", employeeId, models.OWNER).
+		Count(&count).Error
+	return count > 0, err
+}